@@ -0,0 +1,13 @@
+data "synology_filestation_file_content" "deploy_key" {
+  path = "/data/keys/deploy.pub"
+}
+
+data "synology_filestation_file_content" "certificate" {
+  path     = "/data/certs/server.p12"
+  max_size = 65536
+}
+
+resource "local_sensitive_file" "certificate" {
+  filename       = "${path.module}/server.p12"
+  content_base64 = data.synology_filestation_file_content.certificate.content_base64
+}
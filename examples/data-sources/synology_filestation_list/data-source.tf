@@ -0,0 +1,9 @@
+data "synology_filestation_list" "configs" {
+  path     = "/data/projects"
+  patterns = ["*.yml", "*.yaml"]
+  sort_by  = "mtime"
+}
+
+output "config_files" {
+  value = data.synology_filestation_list.configs.files[*].path
+}
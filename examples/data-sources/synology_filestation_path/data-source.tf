@@ -0,0 +1,11 @@
+data "synology_filestation_path" "compose" {
+  path = "/data/projects/app/compose.yml"
+}
+
+output "compose_exists" {
+  value = data.synology_filestation_path.compose.exists
+}
+
+output "compose_modified" {
+  value = data.synology_filestation_path.compose.mtime
+}
@@ -0,0 +1,7 @@
+data "synology_filestation_shares" "writable" {
+  only_writable = true
+}
+
+output "writable_shares" {
+  value = data.synology_filestation_shares.writable.shares[*].path
+}
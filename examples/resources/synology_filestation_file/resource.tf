@@ -0,0 +1,10 @@
+resource "synology_filestation_file" "compose" {
+  path           = "/docker/app/compose.yml"
+  content        = file("${path.module}/compose.yml")
+  create_parents = true
+}
+
+resource "synology_filestation_file" "backup_script" {
+  path   = "/scripts/backup.sh"
+  source = "${path.module}/backup.sh"
+}
@@ -0,0 +1,2 @@
+# Folder is imported by its full path
+terraform import synology_filestation_folder.app /data/projects/app
@@ -0,0 +1,11 @@
+resource "synology_filestation_folder" "app" {
+  path         = "/data/projects"
+  name         = "app"
+  force_parent = true
+}
+
+resource "synology_filestation_folder" "cache" {
+  path             = synology_filestation_folder.app.id
+  name             = "cache"
+  recursive_delete = true
+}
@@ -0,0 +1,167 @@
+package filestation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// defaultMaxContentSize limits size of downloaded files, so large files don't bloat Terraform state by mistake.
+const defaultMaxContentSize = 1 << 20
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &fileContentDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &fileContentDataSource{}
+)
+
+func NewFileContentDataSource() datasource.DataSource {
+	return &fileContentDataSource{}
+}
+
+type fileContentDataSource struct {
+	client client.Client
+}
+
+type fileContentDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Path          types.String `tfsdk:"path"`
+	MaxSize       types.Int64  `tfsdk:"max_size"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+func (d *fileContentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "file_content")
+}
+
+func (d *fileContentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads content of a file, e.g. a generated key or an application config.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Path of the file.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Full path of the file starting with a shared folder, e.g. `/data/app/config.yml`.",
+				Required:    true,
+			},
+			"max_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximal size of the file in bytes, reading larger files fails. Defaults to `%d`.", defaultMaxContentSize),
+				Optional:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the file as text. It is null if the content is not valid UTF-8, use `content_base64` then.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Content of the file encoded with base64.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of the content in hex.",
+				Computed:    true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the file in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *fileContentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *fileContentDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data fileContentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MaxSize.IsNull() && !data.MaxSize.IsUnknown() && data.MaxSize.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "invalid attribute value",
+			fmt.Sprintf("max_size must be positive, got %d", data.MaxSize.ValueInt64()))
+	}
+}
+
+func (d *fileContentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data fileContentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultMaxContentSize)
+	if !data.MaxSize.IsNull() {
+		limit = data.MaxSize.ValueInt64()
+	}
+
+	content := &bytes.Buffer{}
+	clientResponse := filestation.DownloadResponse{Writer: content, Limit: limit}
+	clientRequest := filestation.NewDownloadRequest(2).WithPath(data.Path.ValueString())
+	err := d.client.DoContext(ctx, clientRequest, &clientResponse)
+	switch {
+	case clientResponse.Zipped():
+		resp.Diagnostics.AddError("Unexpected file type", fmt.Sprintf("Path %s is a folder.", data.Path.ValueString()))
+		return
+	case errors.Is(err, filestation.ErrContentTooLarge):
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "File is too large",
+			fmt.Sprintf("File %s is larger than %d bytes.", data.Path.ValueString(), limit))
+		return
+	case err != nil:
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to download file, got error: %s", err))
+		return
+	}
+
+	sum := sha256.Sum256(content.Bytes())
+	data.ID = data.Path
+	data.Content = types.StringNull()
+	if utf8.Valid(content.Bytes()) {
+		data.Content = types.StringValue(content.String())
+	}
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content.Bytes()))
+	data.ContentSHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+	data.Size = types.Int64Value(int64(content.Len()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,104 @@
+package filestation_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileContentDataSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	binary := []byte{0x00, 0xff, 0xfe, 0x01}
+	require.NoError(t, p.DSM.WriteFile("/data/app/config.yml", []byte("key: value\n")))
+	require.NoError(t, p.DSM.WriteFile("/data/app/key.bin", binary))
+
+	testCases := []struct {
+		name            string
+		path            string
+		expectedContent tftypes.Value
+		expectedBase64  string
+		expectedSize    int
+	}{
+		{
+			name:            "text",
+			path:            "/data/app/config.yml",
+			expectedContent: tftypes.NewValue(tftypes.String, "key: value\n"),
+			expectedBase64:  base64.StdEncoding.EncodeToString([]byte("key: value\n")),
+			expectedSize:    11,
+		},
+		{
+			name:            "binary",
+			path:            "/data/app/key.bin",
+			expectedContent: tftypes.NewValue(tftypes.String, nil),
+			expectedBase64:  base64.StdEncoding.EncodeToString(binary),
+			expectedSize:    len(binary),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := p.ReadDataSource("synology_filestation_file_content", map[string]tftypes.Value{
+				"path": tftypes.NewValue(tftypes.String, tc.path),
+			})
+
+			assert.Equal(t, tftypes.NewValue(tftypes.String, tc.path), state["id"])
+			assert.Equal(t, tc.expectedContent, state["content"])
+			assert.Equal(t, tftypes.NewValue(tftypes.String, tc.expectedBase64), state["content_base64"])
+			assert.Equal(t, tftypes.NewValue(tftypes.Number, tc.expectedSize), state["size"])
+		})
+	}
+
+	state := p.ReadDataSource("synology_filestation_file_content", map[string]tftypes.Value{
+		"path":     tftypes.NewValue(tftypes.String, "/data/app/config.yml"),
+		"max_size": tftypes.NewValue(tftypes.Number, 11),
+	})
+	assert.Equal(t, checksum("key: value\n"), state["content_sha256"])
+}
+
+func TestFileContentDataSource_errors(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	require.NoError(t, p.DSM.WriteFile("/data/app/config.yml", []byte("key: value\n")))
+
+	testCases := []struct {
+		name          string
+		config        map[string]tftypes.Value
+		expectedError string
+	}{
+		{
+			name:          "missing file",
+			config:        map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, "/data/app/missing.yml")},
+			expectedError: "API request failed",
+		},
+		{
+			name:          "folder",
+			config:        map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, "/data/app")},
+			expectedError: "Unexpected file type",
+		},
+		{
+			name: "file too large",
+			config: map[string]tftypes.Value{
+				"path":     tftypes.NewValue(tftypes.String, "/data/app/config.yml"),
+				"max_size": tftypes.NewValue(tftypes.Number, 10),
+			},
+			expectedError: "File is too large",
+		},
+		{
+			name: "invalid max size",
+			config: map[string]tftypes.Value{
+				"path":     tftypes.NewValue(tftypes.String, "/data/app/config.yml"),
+				"max_size": tftypes.NewValue(tftypes.Number, 0),
+			},
+			expectedError: "invalid attribute value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertError(t, p.ReadDataSourceDiagnostics("synology_filestation_file_content", tc.config), tc.expectedError)
+		})
+	}
+}
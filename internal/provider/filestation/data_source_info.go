@@ -88,22 +88,15 @@ func (d *infoDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	clientResponse := filestation.FileStationInfoResponse{}
 	clientRequest := filestation.NewFileStationInfoRequest(2)
-	if err := d.client.Do(clientRequest, &clientResponse); err != nil {
+	if err := d.client.DoContext(ctx, clientRequest, &clientResponse); err != nil {
 		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to read data source, got error: %s", err))
 		return
 	}
-	if !clientResponse.Success() {
-		resp.Diagnostics.AddError(
-			"Client error",
-			fmt.Sprintf("Unable to read data source, got error: %s", clientResponse.GetError()),
-		)
-		return
-	}
 
 	data.ID = types.StringValue(clientResponse.Hostname)
 	data.Hostname = types.StringValue(clientResponse.Hostname)
 	data.IsManager = types.BoolValue(clientResponse.IsManager)
-	data.SupportSharing = types.BoolValue(clientResponse.Supportsharing)
+	data.SupportSharing = types.BoolValue(clientResponse.SupportSharing)
 	data.SupportVirtualProtocol = types.StringValue(clientResponse.SupportVirtualProtocol)
 
 	// Save data into Terraform state
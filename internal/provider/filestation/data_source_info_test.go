@@ -0,0 +1,21 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfoDataSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	state := p.ReadDataSource("synology_filestation_info", nil)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "dsmtest"), state["hostname"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "dsmtest"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), state["is_manager"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), state["support_sharing"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "cifs,nfs,iso"), state["support_virtual_protocol"])
+}
@@ -0,0 +1,177 @@
+package filestation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &listDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &listDataSource{}
+)
+
+var (
+	listSortBy = []filestation.SortBy{
+		filestation.SortByName,
+		filestation.SortBySize,
+		filestation.SortByUser,
+		filestation.SortByGroup,
+		filestation.SortByMTime,
+		filestation.SortByATime,
+		filestation.SortByCTime,
+		filestation.SortByCRTime,
+		filestation.SortByPosix,
+		filestation.SortByType,
+	}
+	sortDirections = []filestation.SortDirection{filestation.SortAscending, filestation.SortDescending}
+	fileTypes      = []filestation.FileType{filestation.FileTypeFile, filestation.FileTypeDir, filestation.FileTypeAll}
+)
+
+func NewListDataSource() datasource.DataSource {
+	return &listDataSource{}
+}
+
+type listDataSource struct {
+	client client.Client
+}
+
+type listDataSourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Path          types.String   `tfsdk:"path"`
+	Patterns      []types.String `tfsdk:"patterns"`
+	FileType      types.String   `tfsdk:"file_type"`
+	SortBy        types.String   `tfsdk:"sort_by"`
+	SortDirection types.String   `tfsdk:"sort_direction"`
+	Files         []fileModel    `tfsdk:"files"`
+}
+
+func (d *listDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "list")
+}
+
+func (d *listDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists files and folders in a folder.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Path of the listed folder.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the folder to list, e.g. `/data/projects`.",
+				Required:    true,
+			},
+			"patterns": schema.ListAttribute{
+				Description: "Case-insensitive glob patterns, e.g. `*.txt`, files matching any of them are listed. " +
+					"Patterns without wildcards match names partially. Patterns can't contain commas, as the API sends them as a comma-separated list.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"file_type": schema.StringAttribute{
+				Description: "Type of listed items: `file`, `dir` or `all`. Defaults to `all`.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Attribute to sort by: `name`, `size`, `user`, `group`, `mtime`, `atime`, `ctime`, `crtime`, `posix` or `type`. Defaults to `name`.",
+				Optional:    true,
+			},
+			"sort_direction": schema.StringAttribute{
+				Description: "Sort direction: `asc` or `desc`. Defaults to `asc`.",
+				Optional:    true,
+			},
+			"files": schema.ListNestedAttribute{
+				Description: "Files and folders in the folder.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: fileAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *listDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *listDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data listDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateOneOf(path.Root("file_type"), data.FileType, fileTypes, &resp.Diagnostics)
+	validateOneOf(path.Root("sort_by"), data.SortBy, listSortBy, &resp.Diagnostics)
+	validateOneOf(path.Root("sort_direction"), data.SortDirection, sortDirections, &resp.Diagnostics)
+	for i, pattern := range data.Patterns {
+		if !pattern.IsUnknown() && strings.Contains(pattern.ValueString(), ",") {
+			resp.Diagnostics.AddAttributeError(path.Root("patterns").AtListIndex(i), "invalid attribute value",
+				fmt.Sprintf("pattern must not contain commas, got %q", pattern.ValueString()))
+		}
+	}
+}
+
+func (d *listDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data listDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, err := client.NewPager[filestation.File](d.client, func(offset, limit int) (api.Request, client.Page[filestation.File]) {
+		clientRequest := filestation.NewListRequest(2, data.Path.ValueString()).
+			WithOffset(offset).
+			WithLimit(limit).
+			WithFileType(filestation.FileType(data.FileType.ValueString())).
+			WithSortBy(filestation.SortBy(data.SortBy.ValueString())).
+			WithSortDirection(filestation.SortDirection(data.SortDirection.ValueString())).
+			WithAdditional(fileAdditional...)
+		for _, pattern := range data.Patterns {
+			clientRequest.WithPattern(pattern.ValueString())
+		}
+		return clientRequest, &filestation.ListResponse{}
+	}).All(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to list folder, got error: %s", err))
+		return
+	}
+
+	data.ID = data.Path
+	data.Files = make([]fileModel, 0, len(files))
+	for _, f := range files {
+		data.Files = append(data.Files, newFileModel(f))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,129 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDataSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	require.NoError(t, p.DSM.MkdirAll("/data/projects/app"))
+	require.NoError(t, p.DSM.WriteFile("/data/projects/readme.md", []byte("# projects")))
+	require.NoError(t, p.DSM.WriteFile("/data/projects/compose.yml", []byte("services: {}")))
+
+	testCases := []struct {
+		name          string
+		config        map[string]tftypes.Value
+		expectedNames []string
+	}{
+		{
+			name:          "all",
+			expectedNames: []string{"app", "compose.yml", "readme.md"},
+		},
+		{
+			name: "patterns",
+			config: map[string]tftypes.Value{
+				"patterns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "*.yml"),
+					tftypes.NewValue(tftypes.String, "*.md"),
+				}),
+			},
+			expectedNames: []string{"compose.yml", "readme.md"},
+		},
+		{
+			name:          "folders",
+			config:        map[string]tftypes.Value{"file_type": tftypes.NewValue(tftypes.String, "dir")},
+			expectedNames: []string{"app"},
+		},
+		{
+			name: "sorted",
+			config: map[string]tftypes.Value{
+				"sort_by":        tftypes.NewValue(tftypes.String, "size"),
+				"sort_direction": tftypes.NewValue(tftypes.String, "desc"),
+			},
+			expectedNames: []string{"compose.yml", "readme.md", "app"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, "/data/projects")}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+
+			state := p.ReadDataSource("synology_filestation_list", config)
+			assert.Equal(t, tftypes.NewValue(tftypes.String, "/data/projects"), state["id"])
+			files := listObjects(t, state["files"])
+			assert.Equal(t, tc.expectedNames, stringAttributes(t, files, "name"))
+		})
+	}
+
+	state := p.ReadDataSource("synology_filestation_list", map[string]tftypes.Value{
+		"path":     tftypes.NewValue(tftypes.String, "/data/projects"),
+		"patterns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "readme")}),
+	})
+	files := listObjects(t, state["files"])
+	require.Len(t, files, 1)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/data/projects/readme.md"), files[0]["path"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/volume1/data/projects/readme.md"), files[0]["real_path"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), files[0]["is_dir"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Number, 10), files[0]["size"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "admin"), files[0]["owner"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "644"), files[0]["posix"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "MD"), files[0]["type"])
+	assert.False(t, files[0]["mtime"].IsNull())
+}
+
+func TestListDataSource_errors(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	testCases := []struct {
+		name          string
+		config        map[string]tftypes.Value
+		expectedError string
+	}{
+		{
+			name:          "missing folder",
+			config:        map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, "/data/missing")},
+			expectedError: "API request failed",
+		},
+		{
+			name: "invalid file type",
+			config: map[string]tftypes.Value{
+				"path":      tftypes.NewValue(tftypes.String, "/data"),
+				"file_type": tftypes.NewValue(tftypes.String, "link"),
+			},
+			expectedError: "invalid attribute value",
+		},
+		{
+			name: "invalid sort direction",
+			config: map[string]tftypes.Value{
+				"path":           tftypes.NewValue(tftypes.String, "/data"),
+				"sort_direction": tftypes.NewValue(tftypes.String, "up"),
+			},
+			expectedError: "invalid attribute value",
+		},
+		{
+			name: "pattern with comma",
+			config: map[string]tftypes.Value{
+				"path": tftypes.NewValue(tftypes.String, "/data"),
+				"patterns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "*.txt"),
+					tftypes.NewValue(tftypes.String, "a,b"),
+				}),
+			},
+			expectedError: "invalid attribute value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertError(t, p.ReadDataSourceDiagnostics("synology_filestation_list", tc.config), tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package filestation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &pathDataSource{}
+
+func NewPathDataSource() datasource.DataSource {
+	return &pathDataSource{}
+}
+
+type pathDataSource struct {
+	client client.Client
+}
+
+type pathDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Exists   types.Bool   `tfsdk:"exists"`
+	Path     types.String `tfsdk:"path"`
+	Name     types.String `tfsdk:"name"`
+	IsDir    types.Bool   `tfsdk:"is_dir"`
+	RealPath types.String `tfsdk:"real_path"`
+	Size     types.Int64  `tfsdk:"size"`
+	Owner    types.String `tfsdk:"owner"`
+	Group    types.String `tfsdk:"group"`
+	Posix    types.String `tfsdk:"posix"`
+	Type     types.String `tfsdk:"type"`
+	ATime    types.String `tfsdk:"atime"`
+	MTime    types.String `tfsdk:"mtime"`
+	CTime    types.String `tfsdk:"ctime"`
+	CRTime   types.String `tfsdk:"crtime"`
+}
+
+func (d *pathDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "path")
+}
+
+func (d *pathDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := fileAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Description: "Path of file or folder.",
+		Computed:    true,
+	}
+	attributes["path"] = schema.StringAttribute{
+		Description: "Full path of file or folder starting with a shared folder, e.g. `/data/projects/app`.",
+		Required:    true,
+	}
+	attributes["exists"] = schema.BoolAttribute{
+		Description: "Indicates whether the path exists. Other attributes are null if it does not.",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Metadata of file or folder.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *pathDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *pathDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config pathDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := pathDataSourceModel{
+		ID:       config.Path,
+		Exists:   types.BoolValue(false),
+		Path:     config.Path,
+		Name:     types.StringNull(),
+		IsDir:    types.BoolNull(),
+		RealPath: types.StringNull(),
+		Size:     types.Int64Null(),
+		Owner:    types.StringNull(),
+		Group:    types.StringNull(),
+		Posix:    types.StringNull(),
+		Type:     types.StringNull(),
+		ATime:    types.StringNull(),
+		MTime:    types.StringNull(),
+		CTime:    types.StringNull(),
+		CRTime:   types.StringNull(),
+	}
+
+	clientResponse := filestation.GetInfoResponse{}
+	clientRequest := filestation.NewGetInfoRequest(2).
+		WithPath(config.Path.ValueString()).
+		WithAdditional(fileAdditional...)
+	err := d.client.DoContext(ctx, clientRequest, &clientResponse)
+	switch {
+	case errors.Is(err, api.ErrNotFound):
+	case err != nil:
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to read path, got error: %s", err))
+		return
+	case len(clientResponse.Files) == 0 || clientResponse.Files[0].Code == 408:
+	case clientResponse.Files[0].Code != 0:
+		resp.Diagnostics.AddError("API request failed",
+			fmt.Sprintf("Unable to read path %s, got error code: %d", config.Path.ValueString(), clientResponse.Files[0].Code))
+		return
+	default:
+		file := newFileModel(clientResponse.Files[0])
+		data.Exists = types.BoolValue(true)
+		data.Name = file.Name
+		data.IsDir = file.IsDir
+		data.RealPath = file.RealPath
+		data.Size = file.Size
+		data.Owner = file.Owner
+		data.Group = file.Group
+		data.Posix = file.Posix
+		data.Type = file.Type
+		data.ATime = file.ATime
+		data.MTime = file.MTime
+		data.CTime = file.CTime
+		data.CRTime = file.CRTime
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
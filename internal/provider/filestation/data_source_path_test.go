@@ -0,0 +1,58 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathDataSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	require.NoError(t, p.DSM.MkdirAll("/data/projects/app"))
+	require.NoError(t, p.DSM.WriteFile("/data/projects/app/compose.yml", []byte("services: {}")))
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedExists bool
+		expectedIsDir  bool
+		expectedSize   int64
+		expectedPosix  string
+	}{
+		{name: "folder", path: "/data/projects/app", expectedExists: true, expectedIsDir: true, expectedPosix: "755"},
+		{name: "file", path: "/data/projects/app/compose.yml", expectedExists: true, expectedSize: 12, expectedPosix: "644"},
+		{name: "share", path: "/data", expectedExists: true, expectedIsDir: true, expectedPosix: "755"},
+		{name: "missing", path: "/data/projects/missing"},
+		{name: "missing parent", path: "/data/missing/app"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := p.ReadDataSource("synology_filestation_path", map[string]tftypes.Value{
+				"path": tftypes.NewValue(tftypes.String, tc.path),
+			})
+
+			assert.Equal(t, tftypes.NewValue(tftypes.String, tc.path), state["id"])
+			assert.Equal(t, tftypes.NewValue(tftypes.Bool, tc.expectedExists), state["exists"])
+			if !tc.expectedExists {
+				for _, name := range []string{"name", "is_dir", "real_path", "size", "owner", "mtime"} {
+					assert.True(t, state[name].IsNull(), "%s must be null for missing path", name)
+				}
+				return
+			}
+			assert.Equal(t, tftypes.NewValue(tftypes.Bool, tc.expectedIsDir), state["is_dir"])
+			assert.Equal(t, tftypes.NewValue(tftypes.Number, tc.expectedSize), state["size"])
+			assert.Equal(t, tftypes.NewValue(tftypes.String, tc.expectedPosix), state["posix"])
+			assert.Equal(t, tftypes.NewValue(tftypes.String, "/volume1"+tc.path), state["real_path"])
+			assert.Equal(t, tftypes.NewValue(tftypes.String, "admin"), state["owner"])
+			assert.False(t, state["crtime"].IsNull())
+		})
+	}
+
+	assertError(t, p.ReadDataSourceDiagnostics("synology_filestation_path", map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, "data/relative"),
+	}), "API request failed")
+}
@@ -0,0 +1,240 @@
+package filestation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &sharesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &sharesDataSource{}
+)
+
+var shareSortBy = []filestation.SortBy{
+	filestation.SortByName,
+	filestation.SortByUser,
+	filestation.SortByGroup,
+	filestation.SortByMTime,
+	filestation.SortByATime,
+	filestation.SortByCTime,
+	filestation.SortByCRTime,
+	filestation.SortByPosix,
+}
+
+func NewSharesDataSource() datasource.DataSource {
+	return &sharesDataSource{}
+}
+
+type sharesDataSource struct {
+	client client.Client
+}
+
+type sharesDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	OnlyWritable  types.Bool   `tfsdk:"only_writable"`
+	SortBy        types.String `tfsdk:"sort_by"`
+	SortDirection types.String `tfsdk:"sort_direction"`
+	Shares        []shareModel `tfsdk:"shares"`
+}
+
+type shareModel struct {
+	Path       types.String `tfsdk:"path"`
+	Name       types.String `tfsdk:"name"`
+	RealPath   types.String `tfsdk:"real_path"`
+	Owner      types.String `tfsdk:"owner"`
+	Group      types.String `tfsdk:"group"`
+	Posix      types.String `tfsdk:"posix"`
+	ShareRight types.String `tfsdk:"share_right"`
+	MTime      types.String `tfsdk:"mtime"`
+	CRTime     types.String `tfsdk:"crtime"`
+	FreeSpace  types.Int64  `tfsdk:"free_space"`
+	TotalSpace types.Int64  `tfsdk:"total_space"`
+	ReadOnly   types.Bool   `tfsdk:"read_only"`
+}
+
+func (d *sharesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "shares")
+}
+
+func (d *sharesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists shared folders available to current user.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source.",
+				Computed:    true,
+			},
+			"only_writable": schema.BoolAttribute{
+				Description: "List only shared folders current user can write to.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Attribute to sort by: `name`, `user`, `group`, `mtime`, `atime`, `ctime`, `crtime` or `posix`. Defaults to `name`.",
+				Optional:    true,
+			},
+			"sort_direction": schema.StringAttribute{
+				Description: "Sort direction: `asc` or `desc`. Defaults to `asc`.",
+				Optional:    true,
+			},
+			"shares": schema.ListNestedAttribute{
+				Description: "Shared folders.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description: "Path of shared folder, e.g. `/data`.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of shared folder.",
+							Computed:    true,
+						},
+						"real_path": schema.StringAttribute{
+							Description: "Path on the volume, e.g. `/volume1/data`.",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Name of owner user.",
+							Computed:    true,
+						},
+						"group": schema.StringAttribute{
+							Description: "Name of owner group.",
+							Computed:    true,
+						},
+						"posix": schema.StringAttribute{
+							Description: "POSIX permission in octal notation, e.g. `755`.",
+							Computed:    true,
+						},
+						"share_right": schema.StringAttribute{
+							Description: "Permission of current user: `RW`, `RO` or `-`.",
+							Computed:    true,
+						},
+						"mtime": schema.StringAttribute{
+							Description: "Last modification time in RFC 3339 format.",
+							Computed:    true,
+						},
+						"crtime": schema.StringAttribute{
+							Description: "Creation time in RFC 3339 format.",
+							Computed:    true,
+						},
+						"free_space": schema.Int64Attribute{
+							Description: "Free space of the volume in bytes.",
+							Computed:    true,
+						},
+						"total_space": schema.Int64Attribute{
+							Description: "Total space of the volume in bytes.",
+							Computed:    true,
+						},
+						"read_only": schema.BoolAttribute{
+							Description: "Indicates whether the volume is read-only.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sharesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *sharesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data sharesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateOneOf(path.Root("sort_by"), data.SortBy, shareSortBy, &resp.Diagnostics)
+	validateOneOf(path.Root("sort_direction"), data.SortDirection, sortDirections, &resp.Diagnostics)
+}
+
+func (d *sharesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data sharesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shares, err := client.NewPager[filestation.File](d.client, func(offset, limit int) (api.Request, client.Page[filestation.File]) {
+		clientRequest := filestation.NewListShareRequest(2).
+			WithOffset(offset).
+			WithLimit(limit).
+			WithOnlyWritable(data.OnlyWritable.ValueBool()).
+			WithSortBy(filestation.SortBy(data.SortBy.ValueString())).
+			WithSortDirection(filestation.SortDirection(data.SortDirection.ValueString())).
+			WithAdditional(
+				filestation.AdditionalRealPath,
+				filestation.AdditionalOwner,
+				filestation.AdditionalTime,
+				filestation.AdditionalPerm,
+				filestation.AdditionalVolumeStatus,
+			)
+		return clientRequest, &filestation.ListShareResponse{}
+	}).All(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to list shared folders, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("shares")
+	data.Shares = make([]shareModel, 0, len(shares))
+	for _, share := range shares {
+		additional := filestation.FileAdditional{}
+		if share.Additional != nil {
+			additional = *share.Additional
+		}
+		volume := filestation.VolumeStatus{}
+		if additional.VolumeStatus != nil {
+			volume = *additional.VolumeStatus
+		}
+		data.Shares = append(data.Shares, shareModel{
+			Path:       types.StringValue(share.Path),
+			Name:       types.StringValue(share.Name),
+			RealPath:   types.StringValue(additional.RealPath),
+			Owner:      types.StringValue(additional.Owner.User),
+			Group:      types.StringValue(additional.Owner.Group),
+			Posix:      types.StringValue(strconv.Itoa(additional.Perm.Posix)),
+			ShareRight: types.StringValue(additional.Perm.ShareRight),
+			MTime:      timeValue(additional.Time.MTime),
+			CRTime:     timeValue(additional.Time.CRTime),
+			FreeSpace:  types.Int64Value(volume.FreeSpace),
+			TotalSpace: types.Int64Value(volume.TotalSpace),
+			ReadOnly:   types.BoolValue(volume.ReadOnly),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
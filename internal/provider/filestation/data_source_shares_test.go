@@ -0,0 +1,34 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharesDataSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	p.DSM.AddShare("backup")
+
+	state := p.ReadDataSource("synology_filestation_shares", nil)
+	shares := listObjects(t, state["shares"])
+	assert.Equal(t, []string{"backup", "data"}, stringAttributes(t, shares, "name"))
+	require.Len(t, shares, 2)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/backup"), shares[0]["path"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/volume1/backup"), shares[0]["real_path"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "RW"), shares[0]["share_right"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), shares[0]["read_only"])
+	assert.False(t, shares[0]["total_space"].IsNull())
+
+	state = p.ReadDataSource("synology_filestation_shares", map[string]tftypes.Value{
+		"sort_direction": tftypes.NewValue(tftypes.String, "desc"),
+	})
+	assert.Equal(t, []string{"data", "backup"}, stringAttributes(t, listObjects(t, state["shares"]), "name"))
+
+	assertError(t, p.ReadDataSourceDiagnostics("synology_filestation_shares", map[string]tftypes.Value{
+		"sort_by": tftypes.NewValue(tftypes.String, "size"),
+	}), "invalid attribute value")
+}
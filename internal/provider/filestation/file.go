@@ -0,0 +1,145 @@
+package filestation
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// fileAdditional lists additional attributes requested for files exposed by data sources.
+var fileAdditional = []filestation.Additional{
+	filestation.AdditionalRealPath,
+	filestation.AdditionalSize,
+	filestation.AdditionalOwner,
+	filestation.AdditionalTime,
+	filestation.AdditionalPerm,
+	filestation.AdditionalType,
+}
+
+// fileModel describes file or folder in data sources.
+type fileModel struct {
+	Path     types.String `tfsdk:"path"`
+	Name     types.String `tfsdk:"name"`
+	IsDir    types.Bool   `tfsdk:"is_dir"`
+	RealPath types.String `tfsdk:"real_path"`
+	Size     types.Int64  `tfsdk:"size"`
+	Owner    types.String `tfsdk:"owner"`
+	Group    types.String `tfsdk:"group"`
+	Posix    types.String `tfsdk:"posix"`
+	Type     types.String `tfsdk:"type"`
+	ATime    types.String `tfsdk:"atime"`
+	MTime    types.String `tfsdk:"mtime"`
+	CTime    types.String `tfsdk:"ctime"`
+	CRTime   types.String `tfsdk:"crtime"`
+}
+
+// fileAttributes returns schema of fileModel.
+func fileAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"path": schema.StringAttribute{
+			Description: "Full path starting with a shared folder.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of file or folder.",
+			Computed:    true,
+		},
+		"is_dir": schema.BoolAttribute{
+			Description: "Indicates whether it is a folder.",
+			Computed:    true,
+		},
+		"real_path": schema.StringAttribute{
+			Description: "Path on the volume, e.g. `/volume1/data/file.txt`.",
+			Computed:    true,
+		},
+		"size": schema.Int64Attribute{
+			Description: "Size of file in bytes.",
+			Computed:    true,
+		},
+		"owner": schema.StringAttribute{
+			Description: "Name of owner user.",
+			Computed:    true,
+		},
+		"group": schema.StringAttribute{
+			Description: "Name of owner group.",
+			Computed:    true,
+		},
+		"posix": schema.StringAttribute{
+			Description: "POSIX permission in octal notation, e.g. `755`.",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Upper-cased extension of file, it is empty for folders.",
+			Computed:    true,
+		},
+		"atime": schema.StringAttribute{
+			Description: "Last access time in RFC 3339 format.",
+			Computed:    true,
+		},
+		"mtime": schema.StringAttribute{
+			Description: "Last modification time in RFC 3339 format.",
+			Computed:    true,
+		},
+		"ctime": schema.StringAttribute{
+			Description: "Last change time in RFC 3339 format.",
+			Computed:    true,
+		},
+		"crtime": schema.StringAttribute{
+			Description: "Creation time in RFC 3339 format.",
+			Computed:    true,
+		},
+	}
+}
+
+// newFileModel converts file with additional attributes to model.
+func newFileModel(f filestation.File) fileModel {
+	additional := filestation.FileAdditional{}
+	if f.Additional != nil {
+		additional = *f.Additional
+	}
+
+	return fileModel{
+		Path:     types.StringValue(f.Path),
+		Name:     types.StringValue(f.Name),
+		IsDir:    types.BoolValue(f.IsDir),
+		RealPath: types.StringValue(additional.RealPath),
+		Size:     types.Int64Value(additional.Size),
+		Owner:    types.StringValue(additional.Owner.User),
+		Group:    types.StringValue(additional.Owner.Group),
+		Posix:    types.StringValue(strconv.Itoa(additional.Perm.Posix)),
+		Type:     types.StringValue(additional.Type),
+		ATime:    timeValue(additional.Time.ATime),
+		MTime:    timeValue(additional.Time.MTime),
+		CTime:    timeValue(additional.Time.CTime),
+		CRTime:   timeValue(additional.Time.CRTime),
+	}
+}
+
+// timeValue formats time in RFC 3339 format, zero time is null.
+func timeValue(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+// validateOneOf checks that configured value of the attribute is one of allowed values.
+func validateOneOf[T ~string](attributePath path.Path, value types.String, allowed []T, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	for _, v := range allowed {
+		if value.ValueString() == string(v) {
+			return
+		}
+	}
+	diags.AddAttributeError(attributePath, "invalid attribute value",
+		fmt.Sprintf("expected one of %v, got %q", allowed, value.ValueString()))
+}
@@ -0,0 +1,45 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func testProviderFactory() (tfprotov6.ProviderServer, error) {
+	return providerserver.NewProtocol6WithError(provider.New()())()
+}
+
+// listObjects decodes list of objects, e.g. nested attribute, into attributes of every object.
+func listObjects(t *testing.T, value tftypes.Value) []map[string]tftypes.Value {
+	t.Helper()
+
+	items := []tftypes.Value{}
+	require.NoError(t, value.As(&items))
+	result := []map[string]tftypes.Value{}
+	for _, item := range items {
+		attributes := map[string]tftypes.Value{}
+		require.NoError(t, item.As(&attributes))
+		result = append(result, attributes)
+	}
+
+	return result
+}
+
+// stringAttributes returns values of string attribute of every object.
+func stringAttributes(t *testing.T, objects []map[string]tftypes.Value, name string) []string {
+	t.Helper()
+
+	result := []string{}
+	for _, object := range objects {
+		var v string
+		require.NoError(t, object[name].As(&v))
+		result = append(result, v)
+	}
+
+	return result
+}
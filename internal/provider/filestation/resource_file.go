@@ -0,0 +1,420 @@
+package filestation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &fileResource{}
+	_ resource.ResourceWithConfigure      = &fileResource{}
+	_ resource.ResourceWithModifyPlan     = &fileResource{}
+	_ resource.ResourceWithValidateConfig = &fileResource{}
+)
+
+func NewFileResource() resource.Resource {
+	return &fileResource{}
+}
+
+type fileResource struct {
+	client client.Client
+}
+
+type fileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Path          types.String `tfsdk:"path"`
+	Content       types.String `tfsdk:"content"`
+	Source        types.String `tfsdk:"source"`
+	CreateParents types.Bool   `tfsdk:"create_parents"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+	Size          types.Int64  `tfsdk:"size"`
+	MTime         types.String `tfsdk:"mtime"`
+}
+
+func (r *fileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "file")
+}
+
+func (r *fileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "File on Synology station uploaded via FileStation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Full path of the file.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Full path of the file starting with a shared folder, e.g. `/data/app/compose.yml`. Changing it recreates the file.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the file. Exactly one of `content` and `source` must be set.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"source": schema.StringAttribute{
+				Description: "Path of local file to upload. Exactly one of `content` and `source` must be set.",
+				Optional:    true,
+			},
+			"create_parents": schema.BoolAttribute{
+				Description: "Create missing parent folders on upload. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of uploaded content in hex. " +
+					"It is reset if the file is changed outside of Terraform, so the content is uploaded again. " +
+					"Refresh downloads the file to detect changes keeping its size, which takes time for large files. " +
+					"It is unknown until apply if `source` file doesn't exist at plan time, e.g. it is generated by another resource.",
+				Computed: true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the file in bytes.",
+				Computed:    true,
+			},
+			"mtime": schema.StringAttribute{
+				Description: "Last modification time in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *fileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *fileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data fileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if p := data.Path.ValueString(); !data.Path.IsUnknown() && !data.Path.IsNull() &&
+		(!strings.HasPrefix(p, "/") || p != path.Clean(p) || path.Dir(p) == "/") {
+		resp.Diagnostics.AddAttributeError(tfpath.Root("path"), "invalid file path",
+			fmt.Sprintf("path must be absolute, start with a shared folder and have no trailing slash, got %q", p))
+	}
+	if !data.Content.IsNull() && !data.Source.IsNull() {
+		resp.Diagnostics.AddAttributeError(tfpath.Root("source"), "conflicting attributes",
+			"only one of content and source can be set")
+	}
+	if data.Content.IsNull() && data.Source.IsNull() {
+		resp.Diagnostics.AddAttributeError(tfpath.Root("content"), "missing attribute",
+			"one of content and source must be set")
+	}
+}
+
+// ModifyPlan calculates checksum of desired content, so changes of source file and
+// changes made outside of Terraform, which reset the checksum on refresh, are detected.
+// Source file missing at plan time may be created during apply, so its checksum is left unknown.
+func (r *fileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data fileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Path.IsUnknown() {
+		data.ID = data.Path
+	}
+
+	data.ContentSHA256 = types.StringUnknown()
+	if !data.Content.IsUnknown() && !data.Source.IsUnknown() {
+		sum, err := contentChecksum(data)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// checksum is calculated on upload
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(tfpath.Root("source"), "source file is not readable", err.Error())
+			return
+		default:
+			data.ContentSHA256 = types.StringValue(sum)
+		}
+	}
+
+	var state fileResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !req.State.Raw.IsNull() && data.ContentSHA256.Equal(state.ContentSHA256) {
+		data.Size = state.Size
+		data.MTime = state.MTime
+	} else {
+		data.Size = types.Int64Unknown()
+		data.MTime = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data fileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, &data, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data fileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, found, diags := r.stat(ctx, data.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// the file was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	size, mtime := types.Int64Value(file.Additional.Size), timeValue(file.Additional.Time.MTime)
+	if !size.Equal(data.Size) {
+		// the file was changed outside of Terraform, so its content is unknown
+		data.ContentSHA256 = types.StringValue("")
+	} else if data.ContentSHA256.ValueString() != "" {
+		// the content may be changed keeping both size and mtime, so only checksum is reliable
+		sum, diags := r.remoteChecksum(ctx, data.ID.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if sum != data.ContentSHA256.ValueString() {
+			data.ContentSHA256 = types.StringValue("")
+		}
+	}
+	data.Size = size
+	data.MTime = mtime
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state fileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ContentSHA256.Equal(state.ContentSHA256) {
+		resp.Diagnostics.Append(r.upload(ctx, &data, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data fileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := client.RunTask(ctx, r.client, deleteTask(data.ID.ValueString(), false))
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to delete file, got error: %s", err))
+		return
+	}
+}
+
+// upload sends content of the file and updates computed attributes of data.
+// Existing file is replaced only if overwrite is set.
+func (r *fileResource) upload(ctx context.Context, data *fileResourceModel, overwrite bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sum, err := contentChecksum(*data)
+	if err != nil {
+		diags.AddAttributeError(tfpath.Root("source"), "source file is not readable", err.Error())
+		return diags
+	}
+	content, err := openContent(*data)
+	if err != nil {
+		diags.AddAttributeError(tfpath.Root("source"), "source file is not readable", err.Error())
+		return diags
+	}
+	defer content.Close()
+
+	p := data.Path.ValueString()
+	clientResponse := filestation.UploadResponse{}
+	clientRequest := filestation.NewUploadRequest(2, path.Dir(p), path.Base(p), content).
+		WithCreateParents(data.CreateParents.ValueBool())
+	if overwrite {
+		clientRequest.WithOverwrite(true)
+	}
+	if err := r.client.DoContext(ctx, clientRequest, &clientResponse); err != nil {
+		diags.AddError("API request failed", fmt.Sprintf("Unable to upload file, got error: %s", err))
+		return diags
+	}
+
+	file, found, statDiags := r.stat(ctx, p)
+	diags.Append(statDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if !found {
+		diags.AddError("Unexpected API response", fmt.Sprintf("File %s is not found after upload.", p))
+		return diags
+	}
+
+	data.ID = types.StringValue(p)
+	data.ContentSHA256 = types.StringValue(sum)
+	data.Size = types.Int64Value(file.Additional.Size)
+	data.MTime = timeValue(file.Additional.Time.MTime)
+
+	return diags
+}
+
+// stat returns information about the file, reporting false if it does not exist.
+func (r *fileResource) stat(ctx context.Context, p string) (filestation.File, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clientResponse := filestation.GetInfoResponse{}
+	clientRequest := filestation.NewGetInfoRequest(2).
+		WithPath(p).
+		WithAdditional(filestation.AdditionalSize, filestation.AdditionalTime)
+	err := r.client.DoContext(ctx, clientRequest, &clientResponse)
+	if errors.Is(err, api.ErrNotFound) || (err == nil && (len(clientResponse.Files) == 0 || clientResponse.Files[0].Code == 408)) {
+		return filestation.File{}, false, diags
+	}
+	if err != nil {
+		diags.AddError("API request failed", fmt.Sprintf("Unable to read file, got error: %s", err))
+		return filestation.File{}, false, diags
+	}
+	file := clientResponse.Files[0]
+	if file.Code != 0 {
+		diags.AddError("API request failed", fmt.Sprintf("Unable to read file %s, got error code: %d", p, file.Code))
+		return file, false, diags
+	}
+	if file.IsDir {
+		diags.AddError("Unexpected file type", fmt.Sprintf("Path %s exists, but it is a folder.", p))
+		return file, false, diags
+	}
+	if file.Additional == nil {
+		file.Additional = &filestation.FileAdditional{}
+	}
+
+	return file, true, diags
+}
+
+// remoteChecksum downloads the file and returns SHA-256 checksum of its content in hex.
+func (r *fileResource) remoteChecksum(ctx context.Context, p string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	hash := sha256.New()
+	clientResponse := filestation.DownloadResponse{Writer: hash}
+	clientRequest := filestation.NewDownloadRequest(2).WithPath(p)
+	if err := r.client.DoContext(ctx, clientRequest, &clientResponse); err != nil {
+		diags.AddError("API request failed", fmt.Sprintf("Unable to download file, got error: %s", err))
+		return "", diags
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), diags
+}
+
+// openContent returns reader of desired content, either inline or from source file.
+func openContent(data fileResourceModel) (io.ReadSeekCloser, error) {
+	if !data.Source.IsNull() {
+		return os.Open(data.Source.ValueString())
+	}
+
+	return nopCloser{strings.NewReader(data.Content.ValueString())}, nil
+}
+
+// contentChecksum returns SHA-256 checksum of desired content in hex.
+func contentChecksum(data fileResourceModel) (string, error) {
+	content, err := openContent(data)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,202 @@
+package filestation_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fileResource = "synology_filestation_file"
+
+func checksum(content string) tftypes.Value {
+	sum := sha256.Sum256([]byte(content))
+	return tftypes.NewValue(tftypes.String, hex.EncodeToString(sum[:]))
+}
+
+func fileConfig(p string, attributes map[string]tftypes.Value) map[string]tftypes.Value {
+	config := map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, p)}
+	for k, v := range attributes {
+		config[k] = v
+	}
+
+	return config
+}
+
+func TestFileResource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	config := fileConfig("/data/app/compose.yml", map[string]tftypes.Value{
+		"content":        tftypes.NewValue(tftypes.String, "services: {}"),
+		"create_parents": tftypes.NewValue(tftypes.Bool, true),
+	})
+	state := p.ApplyResource(fileResource, nil, config)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/data/app/compose.yml"), state["id"])
+	assert.Equal(t, checksum("services: {}"), state["content_sha256"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Number, 12), state["size"])
+	assert.False(t, state["mtime"].IsNull())
+	content, err := p.DSM.ReadFile("/data/app/compose.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "services: {}", string(content))
+
+	state = p.ReadResource(fileResource, state)
+	planned, _ := p.PlanResource(fileResource, state, config)
+	assert.Equal(t, state, planned, "unchanged file must not have changes")
+
+	// content change is uploaded in place
+	config["content"] = tftypes.NewValue(tftypes.String, "services:\n  app: {}\n")
+	_, requiresReplace := p.PlanResource(fileResource, state, config)
+	assert.Empty(t, requiresReplace)
+	state = p.ApplyResource(fileResource, state, config)
+	assert.Equal(t, checksum("services:\n  app: {}\n"), state["content_sha256"])
+	content, err = p.DSM.ReadFile("/data/app/compose.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "services:\n  app: {}\n", string(content))
+
+	p.DestroyResource(fileResource, state)
+	assert.False(t, p.DSM.Exists("/data/app/compose.yml"))
+	assert.True(t, p.DSM.IsDir("/data/app"), "parent folder must be kept")
+}
+
+func TestFileResource_source(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	source := filepath.Join(t.TempDir(), "script.sh")
+	require.NoError(t, os.WriteFile(source, []byte("#!/bin/sh\n"), 0o600))
+
+	config := fileConfig("/data/script.sh", map[string]tftypes.Value{
+		"source": tftypes.NewValue(tftypes.String, source),
+	})
+	state := p.ApplyResource(fileResource, nil, config)
+	assert.Equal(t, checksum("#!/bin/sh\n"), state["content_sha256"])
+
+	// change of local file is detected by checksum
+	require.NoError(t, os.WriteFile(source, []byte("#!/bin/sh\necho ok\n"), 0o600))
+	planned, _ := p.PlanResource(fileResource, state, config)
+	assert.Equal(t, checksum("#!/bin/sh\necho ok\n"), planned["content_sha256"])
+	assert.False(t, planned["size"].IsKnown())
+
+	state = p.ApplyResource(fileResource, state, config)
+	content, err := p.DSM.ReadFile("/data/script.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho ok\n", string(content))
+	assert.Equal(t, tftypes.NewValue(tftypes.Number, len(content)), state["size"])
+}
+
+func TestFileResource_generatedSource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	source := filepath.Join(t.TempDir(), "generated.env")
+
+	config := fileConfig("/data/generated.env", map[string]tftypes.Value{
+		"source": tftypes.NewValue(tftypes.String, source),
+	})
+	planned, _ := p.PlanResource(fileResource, nil, config)
+	assert.False(t, planned["content_sha256"].IsKnown(), "checksum of missing source must be left unknown")
+
+	// the source is generated during apply, e.g. by another resource
+	state := p.ApplyResourceAfterPlan(fileResource, nil, config, func() {
+		require.NoError(t, os.WriteFile(source, []byte("KEY=value\n"), 0o600))
+	})
+	assert.Equal(t, checksum("KEY=value\n"), state["content_sha256"])
+	content, err := p.DSM.ReadFile("/data/generated.env")
+	require.NoError(t, err)
+	assert.Equal(t, "KEY=value\n", string(content))
+
+	state = p.ReadResource(fileResource, state)
+	planned, _ = p.PlanResource(fileResource, state, config)
+	assert.Equal(t, state, planned, "unchanged source must not have changes")
+}
+
+func TestFileResource_drift(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	config := fileConfig("/data/file.txt", map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, "managed"),
+	})
+	state := p.ApplyResource(fileResource, nil, config)
+
+	// the file is changed outside of Terraform
+	require.NoError(t, p.DSM.WriteFile("/data/file.txt", []byte("changed manually")))
+	state = p.ReadResource(fileResource, state)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, ""), state["content_sha256"])
+
+	planned, _ := p.PlanResource(fileResource, state, config)
+	assert.Equal(t, checksum("managed"), planned["content_sha256"])
+	state = p.ApplyResource(fileResource, state, config)
+	content, err := p.DSM.ReadFile("/data/file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "managed", string(content))
+
+	// the file is changed outside of Terraform keeping its size
+	require.NoError(t, p.DSM.WriteFile("/data/file.txt", []byte("MANAGED")))
+	state = p.ReadResource(fileResource, state)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, ""), state["content_sha256"])
+	state = p.ApplyResource(fileResource, state, config)
+	content, err = p.DSM.ReadFile("/data/file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "managed", string(content))
+
+	// the file is deleted outside of Terraform
+	require.NoError(t, p.DSM.RemoveAll("/data/file.txt"))
+	assert.Nil(t, p.ReadResource(fileResource, state))
+	p.DestroyResource(fileResource, state)
+}
+
+func TestFileResource_errors(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	require.NoError(t, p.DSM.WriteFile("/data/existing.txt", []byte("content")))
+
+	content := tftypes.NewValue(tftypes.String, "content")
+	testCases := []struct {
+		name          string
+		config        map[string]tftypes.Value
+		expectedError string
+	}{
+		{
+			name:          "no content",
+			config:        fileConfig("/data/file.txt", nil),
+			expectedError: "missing attribute",
+		},
+		{
+			name: "content and source",
+			config: fileConfig("/data/file.txt", map[string]tftypes.Value{
+				"content": content,
+				"source":  tftypes.NewValue(tftypes.String, "file.txt"),
+			}),
+			expectedError: "conflicting attributes",
+		},
+		{
+			name:          "file in root",
+			config:        fileConfig("/file.txt", map[string]tftypes.Value{"content": content}),
+			expectedError: "invalid file path",
+		},
+		{
+			name: "missing source",
+			config: fileConfig("/data/file.txt", map[string]tftypes.Value{
+				"source": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+			}),
+			expectedError: "source file is not readable",
+		},
+		{
+			name:          "missing parent",
+			config:        fileConfig("/data/missing/file.txt", map[string]tftypes.Value{"content": content}),
+			expectedError: "API request failed",
+		},
+		{
+			name:          "existing file",
+			config:        fileConfig("/data/existing.txt", map[string]tftypes.Value{"content": content}),
+			expectedError: "API request failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertError(t, p.ApplyResourceDiagnostics(fileResource, nil, tc.config), tc.expectedError)
+		})
+	}
+}
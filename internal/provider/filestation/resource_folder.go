@@ -0,0 +1,283 @@
+package filestation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &folderResource{}
+	_ resource.ResourceWithConfigure      = &folderResource{}
+	_ resource.ResourceWithImportState    = &folderResource{}
+	_ resource.ResourceWithModifyPlan     = &folderResource{}
+	_ resource.ResourceWithValidateConfig = &folderResource{}
+)
+
+func NewFolderResource() resource.Resource {
+	return &folderResource{}
+}
+
+type folderResource struct {
+	client client.Client
+}
+
+type folderResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Path            types.String `tfsdk:"path"`
+	Name            types.String `tfsdk:"name"`
+	ForceParent     types.Bool   `tfsdk:"force_parent"`
+	RecursiveDelete types.Bool   `tfsdk:"recursive_delete"`
+}
+
+func (r *folderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = buildName(req.ProviderTypeName, "folder")
+}
+
+func (r *folderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Folder on Synology station managed via FileStation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Full path of the folder, e.g. `/data/projects/app`.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the parent folder starting with a shared folder, e.g. `/data/projects`. Changing it recreates the folder.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the folder. Changing it renames the folder in place.",
+				Required:    true,
+			},
+			"force_parent": schema.BoolAttribute{
+				Description: "Create missing parent folders on creation. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"recursive_delete": schema.BoolAttribute{
+				Description: "Delete the folder with all its content on destroy. " +
+					"Destroy of non-empty folder fails otherwise. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *folderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *folderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data folderResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if p := data.Path.ValueString(); !data.Path.IsUnknown() && !data.Path.IsNull() &&
+		(!strings.HasPrefix(p, "/") || p == "/" || p != path.Clean(p)) {
+		resp.Diagnostics.AddAttributeError(tfpath.Root("path"), "invalid folder path",
+			fmt.Sprintf("path must be absolute, start with a shared folder and have no trailing slash, got %q", p))
+	}
+	if name := data.Name.ValueString(); !data.Name.IsUnknown() && !data.Name.IsNull() &&
+		(name == "" || name == "." || name == ".." || strings.Contains(name, "/")) {
+		resp.Diagnostics.AddAttributeError(tfpath.Root("name"), "invalid folder name",
+			fmt.Sprintf("name must be a single path component, got %q", name))
+	}
+}
+
+// ModifyPlan sets ID of created or renamed folder, since it is derived from path and name.
+func (r *folderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data folderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Path.IsUnknown() || data.Name.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("id"), folderID(data.Path.ValueString(), data.Name.ValueString()))...)
+}
+
+func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data folderResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientResponse := filestation.CreateFolderResponse{}
+	clientRequest := filestation.NewCreateFolderRequest(2).
+		WithFolderPath(data.Path.ValueString()).
+		WithName(data.Name.ValueString()).
+		WithForceParent(data.ForceParent.ValueBool())
+	if err := r.client.DoContext(ctx, clientRequest, &clientResponse); err != nil {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to create folder, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(folderID(data.Path.ValueString(), data.Name.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data folderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	clientResponse := filestation.GetInfoResponse{}
+	clientRequest := filestation.NewGetInfoRequest(2).WithPath(id)
+	err := r.client.DoContext(ctx, clientRequest, &clientResponse)
+	if errors.Is(err, api.ErrNotFound) || (err == nil && (len(clientResponse.Files) == 0 || clientResponse.Files[0].Code == 408)) {
+		// the folder was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to read folder, got error: %s", err))
+		return
+	}
+	file := clientResponse.Files[0]
+	if file.Code != 0 {
+		resp.Diagnostics.AddError("API request failed",
+			fmt.Sprintf("Unable to read folder %s, got error code: %d", id, file.Code))
+		return
+	}
+	if !file.IsDir {
+		resp.Diagnostics.AddError("Unexpected file type", fmt.Sprintf("Path %s exists, but it is not a folder.", id))
+		return
+	}
+
+	data.Path = types.StringValue(path.Dir(id))
+	data.Name = types.StringValue(path.Base(id))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state folderResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Name.Equal(state.Name) {
+		clientResponse := filestation.FileStationRenameResponse{}
+		clientRequest := filestation.NewFileStationRenameRequest(2).
+			WithPath(state.ID.ValueString()).
+			WithName(data.Name.ValueString())
+		if err := r.client.DoContext(ctx, clientRequest, &clientResponse); err != nil {
+			resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to rename folder, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(folderID(data.Path.ValueString(), data.Name.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data folderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := client.RunTask(ctx, r.client, deleteTask(data.ID.ValueString(), data.RecursiveDelete.ValueBool()))
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError("API request failed", fmt.Sprintf("Unable to delete folder, got error: %s", err))
+		return
+	}
+}
+
+func (r *folderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := path.Clean(req.ID)
+	if !strings.HasPrefix(id, "/") || path.Dir(id) == "/" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected full path of a folder inside a shared folder, e.g. /data/projects, got: %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &folderResourceModel{
+		ID:              types.StringValue(id),
+		Path:            types.StringValue(path.Dir(id)),
+		Name:            types.StringValue(path.Base(id)),
+		ForceParent:     types.BoolValue(false),
+		RecursiveDelete: types.BoolValue(false),
+	})...)
+}
+
+// folderID returns full path of the folder.
+func folderID(parent, name string) string {
+	return path.Join(parent, name)
+}
+
+// deleteTask returns task deleting path on remote instance.
+func deleteTask(p string, recursive bool) client.Task[*filestation.DeleteStatusResponse] {
+	return client.Task[*filestation.DeleteStatusResponse]{
+		Start: func() (api.Request, client.TaskStart) {
+			return filestation.NewDeleteStartRequest(2).WithPath(p).WithRecursive(recursive), &filestation.DeleteStartResponse{}
+		},
+		Status: func(taskID string) (api.Request, *filestation.DeleteStatusResponse) {
+			return filestation.NewDeleteStatusRequest(2, taskID), &filestation.DeleteStatusResponse{}
+		},
+		Stop: func(taskID string) (api.Request, api.Response) {
+			return filestation.NewDeleteStopRequest(2, taskID), &filestation.DeleteStopResponse{}
+		},
+	}
+}
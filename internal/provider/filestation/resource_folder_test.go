@@ -0,0 +1,126 @@
+package filestation_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const folderResource = "synology_filestation_folder"
+
+func folderConfig(parent, name string, extra map[string]tftypes.Value) map[string]tftypes.Value {
+	config := map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, parent),
+		"name": tftypes.NewValue(tftypes.String, name),
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+
+	return config
+}
+
+func TestFolderResource(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	state := p.ApplyResource(folderResource, nil, folderConfig("/data/projects", "app", map[string]tftypes.Value{
+		"force_parent": tftypes.NewValue(tftypes.Bool, true),
+	}))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/data/projects/app"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), state["recursive_delete"])
+	assert.True(t, p.DSM.IsDir("/data/projects/app"))
+	assert.Equal(t, state, p.ReadResource(folderResource, state))
+
+	// rename in place
+	config := folderConfig("/data/projects", "service", nil)
+	_, requiresReplace := p.PlanResource(folderResource, state, config)
+	assert.Empty(t, requiresReplace)
+	state = p.ApplyResource(folderResource, state, config)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "/data/projects/service"), state["id"])
+	assert.True(t, p.DSM.IsDir("/data/projects/service"))
+	assert.False(t, p.DSM.Exists("/data/projects/app"))
+
+	// parent change recreates the folder
+	_, requiresReplace = p.PlanResource(folderResource, state, folderConfig("/data", "service", nil))
+	assert.Equal(t, []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("path")}, requiresReplace)
+
+	// non-empty folder is kept unless recursive deletion is enabled
+	require.NoError(t, p.DSM.WriteFile("/data/projects/service/file.txt", []byte("content")))
+	assertError(t, p.DestroyResourceDiagnostics(folderResource, state), "API request failed")
+	assert.True(t, p.DSM.Exists("/data/projects/service/file.txt"))
+
+	state = p.ApplyResource(folderResource, state, folderConfig("/data/projects", "service", map[string]tftypes.Value{
+		"recursive_delete": tftypes.NewValue(tftypes.Bool, true),
+	}))
+	p.DestroyResource(folderResource, state)
+	assert.False(t, p.DSM.Exists("/data/projects/service"))
+}
+
+func TestFolderResource_drift(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	state := p.ApplyResource(folderResource, nil, folderConfig("/data", "app", nil))
+	require.NoError(t, p.DSM.RemoveAll("/data/app"))
+	assert.Nil(t, p.ReadResource(folderResource, state), "deleted folder must be removed from state")
+
+	// destroy of already deleted folder succeeds
+	p.DestroyResource(folderResource, state)
+
+	require.NoError(t, p.DSM.WriteFile("/data/app", []byte("content")))
+	assertError(t, p.ReadResourceDiagnostics(folderResource, state), "Unexpected file type")
+}
+
+func TestFolderResource_import(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+	require.NoError(t, p.DSM.MkdirAll("/data/projects/app"))
+
+	state := p.ImportResource(folderResource, "/data/projects/app/")
+	assert.Equal(t, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, "/data/projects/app"),
+		"path":             tftypes.NewValue(tftypes.String, "/data/projects"),
+		"name":             tftypes.NewValue(tftypes.String, "app"),
+		"force_parent":     tftypes.NewValue(tftypes.Bool, false),
+		"recursive_delete": tftypes.NewValue(tftypes.Bool, false),
+	}, state)
+
+	planned, requiresReplace := p.PlanResource(folderResource, state, folderConfig("/data/projects", "app", nil))
+	assert.Equal(t, state, planned, "imported folder must not have changes")
+	assert.Empty(t, requiresReplace)
+}
+
+func TestFolderResource_invalidConfig(t *testing.T) {
+	p := providertest.New(t, testProviderFactory)
+
+	testCases := []struct {
+		name          string
+		config        map[string]tftypes.Value
+		expectedError string
+	}{
+		{name: "relative path", config: folderConfig("data", "app", nil), expectedError: "invalid folder path"},
+		{name: "trailing slash", config: folderConfig("/data/", "app", nil), expectedError: "invalid folder path"},
+		{name: "nested name", config: folderConfig("/data", "app/sub", nil), expectedError: "invalid folder name"},
+		{name: "missing parent", config: folderConfig("/data/missing", "app", nil), expectedError: "API request failed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertError(t, p.ApplyResourceDiagnostics(folderResource, nil, tc.config), tc.expectedError)
+		})
+	}
+}
+
+// assertError checks that diagnostics contain error with summary.
+func assertError(t *testing.T, diagnostics []*tfprotov6.Diagnostic, summary string) {
+	t.Helper()
+
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError && d.Summary == summary {
+			return
+		}
+	}
+	t.Errorf("error %q not found in diagnostics: %v", summary, diagnostics)
+}
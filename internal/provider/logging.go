@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+)
+
+// logRequest logs every request to Synology station, it is shown with TF_LOG=DEBUG.
+// Secrets in request parameters are already masked by the client.
+func logRequest(ctx context.Context, entry client.RequestLog) {
+	fields := map[string]interface{}{
+		"api":           entry.API,
+		"method":        entry.Method,
+		"version":       entry.Version,
+		"http_method":   entry.HTTPMethod,
+		"path":          entry.Path,
+		"params":        entry.Params.Encode(),
+		"status_code":   entry.StatusCode,
+		"response_size": entry.ResponseSize,
+		"duration_ms":   entry.Duration.Milliseconds(),
+	}
+	if len(entry.UnknownFields) > 0 {
+		fields["unknown_fields"] = entry.UnknownFields
+	}
+	if entry.ErrorCode != 0 {
+		fields["error_code"] = entry.ErrorCode
+	}
+	if entry.Err != nil {
+		fields["error"] = entry.Err.Error()
+	}
+
+	tflog.Debug(ctx, "Synology API request", fields)
+}
@@ -2,8 +2,17 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -14,27 +23,72 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/filestation"
 	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/auth"
 )
 
 const (
-	SYNOLOGY_HOST_ENV_VAR            = "SYNOLOGY_HOST"
-	SYNOLOGY_USER_ENV_VAR            = "SYNOLOGY_USER"
-	SYNOLOGY_PASSWORD_ENV_VAR        = "SYNOLOGY_PASSWORD"
-	SYNOLOGY_SKIP_CERT_CHECK_ENV_VAR = "SYNOLOGY_SKIP_CERT_CHECK"
+	SYNOLOGY_HOST_ENV_VAR             = "SYNOLOGY_HOST"
+	SYNOLOGY_USER_ENV_VAR             = "SYNOLOGY_USER"
+	SYNOLOGY_PASSWORD_ENV_VAR         = "SYNOLOGY_PASSWORD"
+	SYNOLOGY_SKIP_CERT_CHECK_ENV_VAR  = "SYNOLOGY_SKIP_CERT_CHECK"
+	SYNOLOGY_OTP_SECRET_ENV_VAR       = "SYNOLOGY_OTP_SECRET"
+	SYNOLOGY_DEVICE_ID_ENV_VAR        = "SYNOLOGY_DEVICE_ID"
+	SYNOLOGY_DEVICE_ID_FILE_ENV_VAR   = "SYNOLOGY_DEVICE_ID_FILE"
+	SYNOLOGY_CA_CERT_ENV_VAR          = "SYNOLOGY_CA_CERT"
+	SYNOLOGY_CA_CERT_FILE_ENV_VAR     = "SYNOLOGY_CA_CERT_FILE"
+	SYNOLOGY_CERT_FINGERPRINT_ENV_VAR = "SYNOLOGY_CERT_FINGERPRINT"
+	SYNOLOGY_CLIENT_CERT_ENV_VAR      = "SYNOLOGY_CLIENT_CERT"
+	SYNOLOGY_CLIENT_CERT_FILE_ENV_VAR = "SYNOLOGY_CLIENT_CERT_FILE"
+	SYNOLOGY_CLIENT_KEY_ENV_VAR       = "SYNOLOGY_CLIENT_KEY"
+	SYNOLOGY_CLIENT_KEY_FILE_ENV_VAR  = "SYNOLOGY_CLIENT_KEY_FILE"
+	SYNOLOGY_TLS_SERVER_NAME_ENV_VAR  = "SYNOLOGY_TLS_SERVER_NAME"
+	SYNOLOGY_PROXY_URL_ENV_VAR        = "SYNOLOGY_PROXY_URL"
+
+	// deviceName is reported to Synology station when trusted device token is requested.
+	deviceName = "terraform-provider-synology"
+
+	defaultSessionName = "webui"
 )
 
 // Ensure SynologyProvider satisfies various provider interfaces.
 var _ provider.Provider = &SynologyProvider{}
+var _ io.Closer = &SynologyProvider{}
 
 // SynologyProvider defines the provider implementation.
-type SynologyProvider struct{}
+type SynologyProvider struct {
+	mu sync.Mutex
+	// clients holds all clients created during provider configuration to terminate their sessions on shutdown.
+	clients []client.Client
+}
 
 // providerModel describes the provider data model.
 type providerModel struct {
-	Host          types.String `tfsdk:"host"`
-	User          types.String `tfsdk:"user"`
-	Password      types.String `tfsdk:"password"`
-	SkipCertCheck types.Bool   `tfsdk:"skip_cert_check"`
+	Host              types.String  `tfsdk:"host"`
+	User              types.String  `tfsdk:"user"`
+	Password          types.String  `tfsdk:"password"`
+	SkipCertCheck     types.Bool    `tfsdk:"skip_cert_check"`
+	OTPCode           types.String  `tfsdk:"otp_code"`
+	OTPSecret         types.String  `tfsdk:"otp_secret"`
+	DeviceID          types.String  `tfsdk:"device_id"`
+	DeviceIDFile      types.String  `tfsdk:"device_id_file"`
+	EnableDeviceToken types.Bool    `tfsdk:"enable_device_token"`
+	SessionName       types.String  `tfsdk:"session_name"`
+	RetryMaxAttempts  types.Int64   `tfsdk:"retry_max_attempts"`
+	RetryMinBackoff   types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff   types.String  `tfsdk:"retry_max_backoff"`
+	RateLimit         types.Float64 `tfsdk:"rate_limit"`
+	RateLimitBurst    types.Int64   `tfsdk:"rate_limit_burst"`
+	MaxConcurrency    types.Int64   `tfsdk:"max_concurrent_requests"`
+	CACert            types.String  `tfsdk:"ca_cert"`
+	CACertFile        types.String  `tfsdk:"ca_cert_file"`
+	CertFingerprint   types.String  `tfsdk:"cert_fingerprint"`
+	ClientCert        types.String  `tfsdk:"client_cert"`
+	ClientCertFile    types.String  `tfsdk:"client_cert_file"`
+	ClientKey         types.String  `tfsdk:"client_key"`
+	ClientKeyFile     types.String  `tfsdk:"client_key_file"`
+	TLSServerName     types.String  `tfsdk:"tls_server_name"`
+	ProxyURL          types.String  `tfsdk:"proxy_url"`
+	ProxyFromEnv      types.Bool    `tfsdk:"proxy_from_environment"`
 }
 
 func (p *SynologyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -45,7 +99,7 @@ func (p *SynologyProvider) Schema(ctx context.Context, req provider.SchemaReques
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "Remote Synology station host in form of 'host:port'.",
+				Description: "Remote Synology station host in form of 'host:port' or URL with scheme and optional path prefix, e.g. 'http://nas.local:5000' or 'https://gw.example.com/nas/'. HTTPS is used if scheme is not set.",
 				Optional:    true,
 			},
 			"user": schema.StringAttribute{
@@ -58,7 +112,99 @@ func (p *SynologyProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Sensitive:   true,
 			},
 			"skip_cert_check": schema.BoolAttribute{
-				Description: "Whether to skip SSL certificate checks.",
+				Description: "Whether to skip SSL certificate checks. Prefer `ca_cert` or `cert_fingerprint` for stations with certificates issued by internal CA or self-signed ones.",
+				Optional:    true,
+			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM-encoded certificates of CA to verify certificate of Synology station with. System CAs are not trusted if set. Can be set with `" + SYNOLOGY_CA_CERT_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to file with PEM-encoded certificates of CA, alternative to `ca_cert`. Can be set with `" + SYNOLOGY_CA_CERT_FILE_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"cert_fingerprint": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of Synology station certificate in hex form, optionally colon-separated. If set, the station is trusted only if its certificate matches the fingerprint, regardless of the issuer. Can be set with `" + SYNOLOGY_CERT_FINGERPRINT_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM-encoded client certificate for mutual TLS authentication, e.g. by reverse proxy in front of Synology station. Requires `client_key` or `client_key_file`. Can be set with `" + SYNOLOGY_CLIENT_CERT_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to file with PEM-encoded client certificate, alternative to `client_cert`. Can be set with `" + SYNOLOGY_CLIENT_CERT_FILE_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of client certificate. Can be set with `" + SYNOLOGY_CLIENT_KEY_ENV_VAR + "` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to file with PEM-encoded private key of client certificate, alternative to `client_key`. Can be set with `" + SYNOLOGY_CLIENT_KEY_FILE_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of HTTP, HTTPS or SOCKS5 proxy to connect to Synology station through, e.g. 'http://proxy.example.com:3128'. Can be set with `" + SYNOLOGY_PROXY_URL_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"proxy_from_environment": schema.BoolAttribute{
+				Description: "Whether to use proxy configured with `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables. Ignored if `proxy_url` is set.",
+				Optional:    true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Server name to verify certificate of Synology station against and to send in SNI, e.g. when the station is accessed by IP address. Can be set with `" + SYNOLOGY_TLS_SERVER_NAME_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"otp_code": schema.StringAttribute{
+				Description: "One-time code for accounts with 2-step verification. The code can be used only once, prefer `otp_secret` or `device_id` for unattended runs.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"otp_secret": schema.StringAttribute{
+				Description: "Base32-encoded secret of 2-step verification to generate one-time codes on every login. Can be set with `" + SYNOLOGY_OTP_SECRET_ENV_VAR + "` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"device_id": schema.StringAttribute{
+				Description: "Trusted device token to skip 2-step verification. Can be set with `" + SYNOLOGY_DEVICE_ID_ENV_VAR + "` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"device_id_file": schema.StringAttribute{
+				Description: "Path to file with trusted device token, alternative to `device_id`. A missing file is not an error, so the token issued with `enable_device_token` is saved there and used on next logins. Can be set with `" + SYNOLOGY_DEVICE_ID_FILE_ENV_VAR + "` environment variable.",
+				Optional:    true,
+			},
+			"session_name": schema.StringAttribute{
+				Description: "Name of the session shown in the list of connections on Synology station. Defaults to `" + defaultSessionName + "`.",
+				Optional:    true,
+			},
+			"enable_device_token": schema.BoolAttribute{
+				Description: "Whether to request trusted device token during login. The issued token is written into `device_id_file`, it is never shown in the output.",
+				Optional:    true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of attempts for requests failed with transient errors, e.g. when Synology station is busy or drops connections. Set to `1` to disable retries. Defaults to `%d`.", client.DefaultRetryMaxAttempts),
+				Optional:    true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Description: fmt.Sprintf("Delay before the first retry as a duration string, e.g. `500ms`. The delay doubles with every next attempt. Defaults to `%s`.", client.DefaultRetryMinBackoff),
+				Optional:    true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: fmt.Sprintf("Upper limit of delay between attempts as a duration string, e.g. `5s`. Defaults to `%s`.", client.DefaultRetryMaxBackoff),
+				Optional:    true,
+			},
+			"rate_limit": schema.Float64Attribute{
+				Description: "Maximum number of API requests per second sent to Synology station. Bursts of requests may trigger auto block of the IP address on Synology station. Not limited by default.",
+				Optional:    true,
+			},
+			"rate_limit_burst": schema.Int64Attribute{
+				Description: "Number of API requests which can be sent at once when `rate_limit` is set. Defaults to `rate_limit` rounded up.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight, regardless of Terraform parallelism. Not limited by default.",
 				Optional:    true,
 			},
 		},
@@ -93,11 +239,47 @@ func (p *SynologyProvider) Configure(ctx context.Context, req provider.Configure
 		}
 	}
 
+	otpSecret := data.OTPSecret.ValueString()
+	if v := os.Getenv(SYNOLOGY_OTP_SECRET_ENV_VAR); v != "" {
+		otpSecret = v
+	}
+
+	deviceID := data.DeviceID.ValueString()
+	if v := os.Getenv(SYNOLOGY_DEVICE_ID_ENV_VAR); v != "" {
+		deviceID = v
+	}
+	deviceIDFile := data.DeviceIDFile.ValueString()
+	if v := os.Getenv(SYNOLOGY_DEVICE_ID_FILE_ENV_VAR); v != "" {
+		deviceIDFile = v
+	}
+	if deviceID == "" && deviceIDFile != "" {
+		deviceID = readDeviceID(deviceIDFile, &resp.Diagnostics)
+	}
+
+	loginOptions := []client.LoginOption{}
+	if v := data.OTPCode.ValueString(); v != "" {
+		loginOptions = append(loginOptions, client.WithOTPCode(v))
+	}
+	if otpSecret != "" {
+		loginOptions = append(loginOptions, client.WithOTPSecret(otpSecret))
+	}
+	if deviceID != "" {
+		loginOptions = append(loginOptions, client.WithDeviceID(deviceID))
+	}
+	if data.EnableDeviceToken.ValueBool() {
+		loginOptions = append(loginOptions, client.WithDeviceToken(deviceName))
+	}
+
 	if host == "" {
 		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
 			path.Root("host"),
 			"invalid provider configuration",
 			"host information is not provided"))
+	} else if _, err := client.ParseHost(host); err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("host"),
+			"invalid provider configuration",
+			err.Error()))
 	}
 	if user == "" {
 		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
@@ -111,26 +293,248 @@ func (p *SynologyProvider) Configure(ctx context.Context, req provider.Configure
 			"invalid provider configuration",
 			"password information is not provided"))
 	}
+	clientOptions := tlsOptions(data, &resp.Diagnostics)
+	if option := proxyOption(data, &resp.Diagnostics); option != nil {
+		clientOptions = append(clientOptions, option)
+	}
+	retryPolicy := client.DefaultRetryPolicy()
+	if !data.RetryMaxAttempts.IsNull() {
+		retryPolicy.MaxAttempts = int(data.RetryMaxAttempts.ValueInt64())
+		if retryPolicy.MaxAttempts < 1 {
+			resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+				path.Root("retry_max_attempts"),
+				"invalid provider configuration",
+				"number of attempts must be positive"))
+		}
+	}
+	if v := data.RateLimit.ValueFloat64(); v < 0 {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("rate_limit"),
+			"invalid provider configuration",
+			"rate limit must not be negative"))
+	}
+	if v := data.MaxConcurrency.ValueInt64(); v < 0 {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("max_concurrent_requests"),
+			"invalid provider configuration",
+			"number of concurrent requests must not be negative"))
+	}
+	retryPolicy.MinBackoff = parseDuration(path.Root("retry_min_backoff"), data.RetryMinBackoff, retryPolicy.MinBackoff, &resp.Diagnostics)
+	retryPolicy.MaxBackoff = parseDuration(path.Root("retry_max_backoff"), data.RetryMaxBackoff, retryPolicy.MaxBackoff, &resp.Diagnostics)
+	if retryPolicy.MinBackoff > retryPolicy.MaxBackoff {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("retry_min_backoff"),
+			"invalid provider configuration",
+			fmt.Sprintf("minimal backoff %s must not exceed maximal backoff %s", retryPolicy.MinBackoff, retryPolicy.MaxBackoff)))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Example client configuration for data sources and resources
-	client, err := client.New(host, skipCertificateCheck)
+	clientOptions = append(clientOptions,
+		client.WithAPIErrors(),
+		client.WithRequestLogger(logRequest),
+		client.WithRetryPolicy(retryPolicy),
+		client.WithRateLimit(data.RateLimit.ValueFloat64(), int(data.RateLimitBurst.ValueInt64())),
+		client.WithMaxConcurrentRequests(int(data.MaxConcurrency.ValueInt64())),
+	)
+	client, err := client.New(host, skipCertificateCheck, clientOptions...)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("synology client creation failed", err.Error()))
+		return
 	}
-	if err := client.Login(user, password, "webui"); err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("login to Synology station failed", err.Error()))
+	sessionName := defaultSessionName
+	if v := data.SessionName.ValueString(); v != "" {
+		sessionName = v
+	}
+	if err := client.LoginContext(ctx, user, password, sessionName, loginOptions...); err != nil {
+		resp.Diagnostics.Append(loginDiagnostic(user, err))
+		return
+	}
+	p.mu.Lock()
+	p.clients = append(p.clients, client)
+	p.mu.Unlock()
+	if data.EnableDeviceToken.ValueBool() && client.DeviceID() != "" && client.DeviceID() != deviceID {
+		saveDeviceID(deviceIDFile, client.DeviceID(), &resp.Diagnostics)
 	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// proxyOption returns client option to connect through proxy, if it is configured.
+func proxyOption(data providerModel, diags *diag.Diagnostics) client.Option {
+	proxyURL := data.ProxyURL.ValueString()
+	if v := os.Getenv(SYNOLOGY_PROXY_URL_ENV_VAR); v != "" {
+		proxyURL = v
+	}
+	if proxyURL == "" {
+		if data.ProxyFromEnv.ValueBool() {
+			return client.WithProxy(http.ProxyFromEnvironment)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err == nil {
+		switch {
+		case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+			err = errors.New("proxy scheme must be one of http, https or socks5")
+		case u.Hostname() == "":
+			err = errors.New("proxy host name is missing")
+		}
+	}
+	if err != nil {
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("proxy_url"),
+			"invalid provider configuration",
+			err.Error()))
+		return nil
+	}
+
+	return client.WithProxy(http.ProxyURL(u))
+}
+
+// parseDuration returns duration set in the attribute or defaultValue if it is not set.
+// Invalid values are reported to diagnostics.
+func parseDuration(attributePath path.Path, value types.String, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.ValueString() == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err == nil && d < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			attributePath,
+			"invalid provider configuration",
+			err.Error()))
+		return defaultValue
+	}
+
+	return d
+}
+
+// readDeviceID returns trusted device token saved in file, missing file means no token was issued yet.
+func readDeviceID(file string, diags *diag.Diagnostics) string {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ""
+	}
+	if err != nil {
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("device_id_file"),
+			"invalid provider configuration",
+			err.Error()))
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+// saveDeviceID writes issued trusted device token into file readable only by the owner.
+// The token itself is never put into diagnostics, as they end up in logs and CI output.
+func saveDeviceID(file, deviceID string, diags *diag.Diagnostics) {
+	if file == "" {
+		diags.AddWarning(
+			"trusted device token is not saved",
+			"Trusted device token was issued but device_id_file is not set, so the token is discarded. Set device_id_file to reuse the token on next logins.")
+		return
+	}
+
+	if err := os.WriteFile(file, []byte(deviceID+"\n"), 0o600); err != nil {
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root("device_id_file"),
+			"trusted device token is not saved",
+			err.Error()))
+		return
+	}
+	diags.AddWarning(
+		"trusted device token issued",
+		fmt.Sprintf("Trusted device token was saved to %s, it is used to skip 2-step verification on next logins.", file))
+}
+
+// loginDiagnostic translates login error into diagnostic with actionable details.
+func loginDiagnostic(user string, err error) diag.Diagnostic {
+	const summary = "login to Synology station failed"
+
+	loginErr := client.LoginError{}
+	if !errors.As(err, &loginErr) {
+		return diag.NewErrorDiagnostic(summary, err.Error())
+	}
+
+	switch loginErr.Code {
+	case auth.ErrorIncorrectCredentials:
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("password"),
+			summary,
+			fmt.Sprintf("no such account or incorrect password for user %q", user))
+	case auth.ErrorAccountDisabled:
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("user"),
+			summary,
+			fmt.Sprintf("account of user %q is disabled", user))
+	case auth.ErrorPermissionDenied:
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("user"),
+			summary,
+			fmt.Sprintf("user %q is not permitted to log in, check application privileges of the account", user))
+	case auth.ErrorOTPRequired, auth.ErrorOTPEnforced:
+		return diag.NewErrorDiagnostic(
+			summary,
+			fmt.Sprintf("2-step verification code is required for user %q, set otp_code, otp_secret or device_id", user))
+	case auth.ErrorOTPFailed:
+		return diag.NewErrorDiagnostic(
+			summary,
+			"2-step verification code is incorrect or expired")
+	case auth.ErrorIPBlocked:
+		return diag.NewErrorDiagnostic(
+			summary,
+			"IP address of this host is blocked by Synology station, check auto block settings")
+	case auth.ErrorPasswordExpiredCantChange, auth.ErrorPasswordExpired, auth.ErrorPasswordMustChange:
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("password"),
+			summary,
+			fmt.Sprintf("password of user %q has expired or must be changed: %s", user, loginErr.Summary))
+	}
+
+	return diag.NewErrorDiagnostic(summary, err.Error())
+}
+
+// Close terminates sessions of all clients created by the provider.
+// It is expected to be called once provider server is stopped.
+func (p *SynologyProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var result error
+	for _, c := range p.clients {
+		if err := c.Logout(); err != nil && result == nil {
+			result = fmt.Errorf("logout from Synology station failed: %w", err)
+		}
+	}
+	p.clients = nil
+
+	return result
+}
+
 func (p *SynologyProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		filestation.NewFileResource,
+		filestation.NewFolderResource,
+	}
 }
 
 func (p *SynologyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		filestation.NewFileContentDataSource,
 		filestation.NewInfoDataSource,
+		filestation.NewListDataSource,
+		filestation.NewPathDataSource,
+		filestation.NewSharesDataSource,
 	}
 }
 
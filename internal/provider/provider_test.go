@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider/providertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -16,3 +23,74 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestConfigure_deviceToken(t *testing.T) {
+	p := providertest.New(t, testAccProtoV6ProviderFactories["synology"])
+	p.DSM.SetOTPCode(providertest.User, "123456")
+	deviceIDFile := filepath.Join(t.TempDir(), "device_id")
+
+	diagnostics := p.ConfigureDiagnostics(map[string]tftypes.Value{
+		"otp_code":            tftypes.NewValue(tftypes.String, "123456"),
+		"enable_device_token": tftypes.NewValue(tftypes.Bool, true),
+		"device_id_file":      tftypes.NewValue(tftypes.String, deviceIDFile),
+	})
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, diagnostics[0].Severity)
+	content, err := os.ReadFile(deviceIDFile)
+	require.NoError(t, err)
+	deviceID := strings.TrimSpace(string(content))
+	require.NotEmpty(t, deviceID)
+	assert.NotContains(t, diagnostics[0].Detail, deviceID, "token must not be shown")
+	info, err := os.Stat(deviceIDFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// saved token skips 2-step verification
+	diagnostics = p.ConfigureDiagnostics(map[string]tftypes.Value{
+		"enable_device_token": tftypes.NewValue(tftypes.Bool, true),
+		"device_id_file":      tftypes.NewValue(tftypes.String, deviceIDFile),
+	})
+	assert.Empty(t, diagnostics)
+
+	// issued token is discarded without a file
+	diagnostics = p.ConfigureDiagnostics(map[string]tftypes.Value{
+		"otp_code":            tftypes.NewValue(tftypes.String, "123456"),
+		"enable_device_token": tftypes.NewValue(tftypes.Bool, true),
+	})
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "trusted device token is not saved", diagnostics[0].Summary)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, diagnostics[0].Severity)
+}
+
+func TestConfigure_retryBackoff(t *testing.T) {
+	p := providertest.New(t, testAccProtoV6ProviderFactories["synology"])
+
+	testCases := []struct {
+		name          string
+		minBackoff    string
+		maxBackoff    string
+		expectedError string
+	}{
+		{name: "valid", minBackoff: "1s", maxBackoff: "10s"},
+		{name: "equal", minBackoff: "1s", maxBackoff: "1s"},
+		{name: "invalid duration", minBackoff: "soon", maxBackoff: "10s", expectedError: "time: invalid duration \"soon\""},
+		{name: "negative", minBackoff: "1s", maxBackoff: "-1s", expectedError: "duration must not be negative"},
+		{name: "min exceeds max", minBackoff: "10s", maxBackoff: "1s", expectedError: "minimal backoff 10s must not exceed maximal backoff 1s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diagnostics := p.ConfigureDiagnostics(map[string]tftypes.Value{
+				"retry_min_backoff": tftypes.NewValue(tftypes.String, tc.minBackoff),
+				"retry_max_backoff": tftypes.NewValue(tftypes.String, tc.maxBackoff),
+			})
+			if tc.expectedError == "" {
+				assert.Empty(t, diagnostics)
+				return
+			}
+			require.Len(t, diagnostics, 1)
+			assert.Equal(t, tfprotov6.DiagnosticSeverityError, diagnostics[0].Severity)
+			assert.Equal(t, tc.expectedError, diagnostics[0].Detail)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package providertest runs provider against in-memory DSM emulator
+// through Terraform plugin protocol, so data sources and resources can be tested
+// end-to-end without Terraform CLI and real hardware.
+package providertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/dsmtest"
+)
+
+const (
+	// User is the account provider is configured with.
+	User = "admin"
+	// Password is the password of User.
+	Password = "secret"
+	// Share is a shared folder created in emulator.
+	Share = "data"
+)
+
+// Provider is a configured provider server connected to DSM emulator.
+type Provider struct {
+	t      *testing.T
+	server tfprotov6.ProviderServer
+	schema *tfprotov6.GetProviderSchemaResponse
+
+	// DSM is the emulator provider is connected to.
+	DSM *dsmtest.Server
+}
+
+// New starts DSM emulator and configures provider server created by factory to use it.
+func New(t *testing.T, factory func() (tfprotov6.ProviderServer, error)) *Provider {
+	t.Helper()
+
+	dsm := dsmtest.NewServer()
+	t.Cleanup(dsm.Close)
+	dsm.AddUser(User, Password)
+	dsm.AddShare(Share)
+
+	server, err := factory()
+	if err != nil {
+		t.Fatalf("provider server creation failed: %s", err)
+	}
+	p := &Provider{t: t, server: server, DSM: dsm}
+
+	p.schema, err = server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema failed: %s", err)
+	}
+	p.checkDiagnostics("GetProviderSchema", p.schema.Diagnostics)
+
+	p.checkDiagnostics("ConfigureProvider", p.ConfigureDiagnostics(nil))
+
+	return p
+}
+
+// ConfigureDiagnostics configures provider with emulator credentials and given attributes,
+// returning its diagnostics without failing the test. Given attributes override the default ones.
+func (p *Provider) ConfigureDiagnostics(attributes map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+
+	config := map[string]tftypes.Value{
+		"host":            tftypes.NewValue(tftypes.String, p.DSM.Host()),
+		"user":            tftypes.NewValue(tftypes.String, User),
+		"password":        tftypes.NewValue(tftypes.String, Password),
+		"skip_cert_check": tftypes.NewValue(tftypes.Bool, true),
+	}
+	for k, v := range attributes {
+		config[k] = v
+	}
+	resp, err := p.server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: p.dynamicValue(p.schema.Provider, config),
+	})
+	if err != nil {
+		p.t.Fatalf("ConfigureProvider failed: %s", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// ReadDataSource reads data source with given configuration and returns its state.
+// Attributes missing in config are null.
+func (p *Provider) ReadDataSource(typeName string, config map[string]tftypes.Value) map[string]tftypes.Value {
+	p.t.Helper()
+
+	schema := p.dataSourceSchema(typeName)
+	state, diagnostics := p.readDataSource(typeName, config)
+	p.checkDiagnostics("ReadDataSource", diagnostics)
+
+	return p.objectAttributes(schema, state)
+}
+
+// ReadDataSourceDiagnostics reads data source and returns its diagnostics without failing the test.
+func (p *Provider) ReadDataSourceDiagnostics(typeName string, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+
+	_, diagnostics := p.readDataSource(typeName, config)
+
+	return diagnostics
+}
+
+// readDataSource validates configuration and reads data source, returning its state and diagnostics of the failed step.
+func (p *Provider) readDataSource(typeName string, config map[string]tftypes.Value) (*tfprotov6.DynamicValue, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+
+	schema := p.dataSourceSchema(typeName)
+	validation, err := p.server.ValidateDataResourceConfig(context.Background(), &tfprotov6.ValidateDataResourceConfigRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(schema, config),
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateDataResourceConfig failed: %s", err)
+	}
+	for _, d := range validation.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, validation.Diagnostics
+		}
+	}
+
+	resp, err := p.server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(schema, config),
+	})
+	if err != nil {
+		p.t.Fatalf("ReadDataSource failed: %s", err)
+	}
+
+	return resp.State, resp.Diagnostics
+}
+
+func (p *Provider) dataSourceSchema(typeName string) *tfprotov6.Schema {
+	p.t.Helper()
+
+	schema, ok := p.schema.DataSourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("data source %s is not registered", typeName)
+	}
+
+	return schema
+}
+
+// dynamicValue encodes attributes as object of schema type, absent attributes are null.
+func (p *Provider) dynamicValue(schema *tfprotov6.Schema, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := attributes[name]; ok {
+			values[name] = v
+			continue
+		}
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		p.t.Fatalf("value encoding failed: %s", err)
+	}
+
+	return &dv
+}
+
+// objectAttributes decodes value of schema type into attributes.
+func (p *Provider) objectAttributes(schema *tfprotov6.Schema, dv *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	p.t.Helper()
+
+	if dv == nil {
+		return nil
+	}
+	value, err := dv.Unmarshal(schema.ValueType())
+	if err != nil {
+		p.t.Fatalf("value decoding failed: %s", err)
+	}
+	if value.IsNull() {
+		return nil
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		p.t.Fatalf("value decoding failed: %s", err)
+	}
+
+	return attributes
+}
+
+func (p *Provider) checkDiagnostics(operation string, diagnostics []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			p.t.Fatalf("%s failed: %s: %s", operation, d.Summary, d.Detail)
+		}
+	}
+}
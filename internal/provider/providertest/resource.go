@@ -0,0 +1,243 @@
+package providertest
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// PlanResource plans change of resource from prior state to configuration,
+// returning planned state and paths of attributes requiring replacement.
+// Nil prior state plans creation.
+func (p *Provider) PlanResource(typeName string, prior, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tftypes.AttributePath) {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	resp := p.planResourceChange(typeName, prior, config)
+	p.checkDiagnostics("PlanResourceChange", resp.Diagnostics)
+
+	return p.objectAttributes(schema, resp.PlannedState), resp.RequiresReplace
+}
+
+// ApplyResource plans and applies change of resource from prior state to configuration,
+// the way 'terraform apply' does, and returns new state.
+// Nil prior state creates the resource.
+func (p *Provider) ApplyResource(typeName string, prior, config map[string]tftypes.Value) map[string]tftypes.Value {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	resp, diagnostics := p.applyResource(typeName, prior, config, nil)
+	p.checkDiagnostics("ApplyResourceChange", diagnostics)
+
+	return p.objectAttributes(schema, resp)
+}
+
+// ApplyResourceAfterPlan is like ApplyResource, but calls beforeApply between planning and applying,
+// e.g. to emulate a file created by another resource during the same 'terraform apply'.
+func (p *Provider) ApplyResourceAfterPlan(typeName string, prior, config map[string]tftypes.Value, beforeApply func()) map[string]tftypes.Value {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	resp, diagnostics := p.applyResource(typeName, prior, config, beforeApply)
+	p.checkDiagnostics("ApplyResourceChange", diagnostics)
+
+	return p.objectAttributes(schema, resp)
+}
+
+// ApplyResourceDiagnostics plans and applies change of resource and returns diagnostics without failing the test.
+func (p *Provider) ApplyResourceDiagnostics(typeName string, prior, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+
+	_, diagnostics := p.applyResource(typeName, prior, config, nil)
+
+	return diagnostics
+}
+
+// DestroyResource destroys resource with given state.
+func (p *Provider) DestroyResource(typeName string, state map[string]tftypes.Value) {
+	p.t.Helper()
+
+	p.checkDiagnostics("ApplyResourceChange", p.DestroyResourceDiagnostics(typeName, state))
+}
+
+// DestroyResourceDiagnostics destroys resource and returns diagnostics without failing the test.
+func (p *Provider) DestroyResourceDiagnostics(typeName string, state map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	resp, err := p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   p.dynamicValue(schema, state),
+		PlannedState: p.nullValue(schema),
+		Config:       p.nullValue(schema),
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange failed: %s", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// ReadResource refreshes resource state, nil is returned if resource is gone.
+func (p *Provider) ReadResource(typeName string, state map[string]tftypes.Value) map[string]tftypes.Value {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	resp, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: p.dynamicValue(schema, state),
+	})
+	if err != nil {
+		p.t.Fatalf("ReadResource failed: %s", err)
+	}
+	p.checkDiagnostics("ReadResource", resp.Diagnostics)
+
+	return p.objectAttributes(schema, resp.NewState)
+}
+
+// ReadResourceDiagnostics refreshes resource state and returns diagnostics without failing the test.
+func (p *Provider) ReadResourceDiagnostics(typeName string, state map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+
+	resp, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: p.dynamicValue(p.resourceSchema(typeName), state),
+	})
+	if err != nil {
+		p.t.Fatalf("ReadResource failed: %s", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// ImportResource imports resource by ID and refreshes its state, the way 'terraform import' does.
+func (p *Provider) ImportResource(typeName, id string) map[string]tftypes.Value {
+	p.t.Helper()
+
+	resp, err := p.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	if err != nil {
+		p.t.Fatalf("ImportResourceState failed: %s", err)
+	}
+	p.checkDiagnostics("ImportResourceState", resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		p.t.Fatalf("ImportResourceState returned %d resources, expected 1", len(resp.ImportedResources))
+	}
+
+	return p.ReadResource(typeName, p.objectAttributes(p.resourceSchema(typeName), resp.ImportedResources[0].State))
+}
+
+// applyResource plans and applies change, returning new state and diagnostics of the failed step.
+// beforeApply, if set, is called once the change is planned.
+func (p *Provider) applyResource(typeName string, prior, config map[string]tftypes.Value, beforeApply func()) (*tfprotov6.DynamicValue, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	plan := p.planResourceChange(typeName, prior, config)
+	for _, d := range plan.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, plan.Diagnostics
+		}
+	}
+	if beforeApply != nil {
+		beforeApply()
+	}
+
+	priorState := p.nullValue(schema)
+	if prior != nil {
+		priorState = p.dynamicValue(schema, prior)
+	}
+	resp, err := p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     priorState,
+		PlannedState:   plan.PlannedState,
+		Config:         p.dynamicValue(schema, config),
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange failed: %s", err)
+	}
+
+	return resp.NewState, resp.Diagnostics
+}
+
+// planResourceChange validates configuration and plans change, validation failures are returned as plan diagnostics.
+func (p *Provider) planResourceChange(typeName string, prior, config map[string]tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	p.t.Helper()
+
+	schema := p.resourceSchema(typeName)
+	validation, err := p.server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(schema, config),
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateResourceConfig failed: %s", err)
+	}
+	for _, d := range validation.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return &tfprotov6.PlanResourceChangeResponse{Diagnostics: validation.Diagnostics}
+		}
+	}
+
+	priorState := p.nullValue(schema)
+	if prior != nil {
+		priorState = p.dynamicValue(schema, prior)
+	}
+	resp, err := p.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       priorState,
+		ProposedNewState: p.dynamicValue(schema, proposedNewState(schema, prior, config)),
+		Config:           p.dynamicValue(schema, config),
+	})
+	if err != nil {
+		p.t.Fatalf("PlanResourceChange failed: %s", err)
+	}
+
+	return resp
+}
+
+// proposedNewState merges prior state into configuration the way Terraform does before planning:
+// computed attributes absent in configuration keep their prior values.
+func proposedNewState(schema *tfprotov6.Schema, prior, config map[string]tftypes.Value) map[string]tftypes.Value {
+	result := map[string]tftypes.Value{}
+	for name, v := range config {
+		result[name] = v
+	}
+	if prior == nil {
+		return result
+	}
+	for _, attr := range schema.Block.Attributes {
+		priorValue, hasPrior := prior[attr.Name]
+		if v, ok := result[attr.Name]; attr.Computed && hasPrior && (!ok || v.IsNull()) {
+			result[attr.Name] = priorValue
+		}
+	}
+
+	return result
+}
+
+func (p *Provider) resourceSchema(typeName string) *tfprotov6.Schema {
+	p.t.Helper()
+
+	schema, ok := p.schema.ResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("resource %s is not registered", typeName)
+	}
+
+	return schema
+}
+
+// nullValue encodes null object of schema type, e.g. absent state.
+func (p *Provider) nullValue(schema *tfprotov6.Schema) *tfprotov6.DynamicValue {
+	p.t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(schema.ValueType(), tftypes.NewValue(schema.ValueType(), nil))
+	if err != nil {
+		p.t.Fatalf("value encoding failed: %s", err)
+	}
+
+	return &dv
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+)
+
+// tlsOptions builds client options from TLS attributes of the provider.
+// Environment variables take precedence over attributes, invalid values are reported to diagnostics.
+func tlsOptions(data providerModel, diags *diag.Diagnostics) []client.Option {
+	options := []client.Option{}
+
+	caCert, caCertPath := pemSource{
+		attribute:     "ca_cert",
+		value:         data.CACert,
+		envVar:        SYNOLOGY_CA_CERT_ENV_VAR,
+		fileAttribute: "ca_cert_file",
+		file:          data.CACertFile,
+		fileEnvVar:    SYNOLOGY_CA_CERT_FILE_ENV_VAR,
+	}.load(diags)
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if pool.AppendCertsFromPEM(caCert) {
+			options = append(options, client.WithRootCAs(pool))
+		} else {
+			diags.Append(diag.NewAttributeErrorDiagnostic(
+				caCertPath,
+				"invalid provider configuration",
+				"no PEM-encoded certificates found"))
+		}
+	}
+
+	clientCert, clientCertPath := pemSource{
+		attribute:     "client_cert",
+		value:         data.ClientCert,
+		envVar:        SYNOLOGY_CLIENT_CERT_ENV_VAR,
+		fileAttribute: "client_cert_file",
+		file:          data.ClientCertFile,
+		fileEnvVar:    SYNOLOGY_CLIENT_CERT_FILE_ENV_VAR,
+	}.load(diags)
+	clientKey, clientKeyPath := pemSource{
+		attribute:     "client_key",
+		value:         data.ClientKey,
+		envVar:        SYNOLOGY_CLIENT_KEY_ENV_VAR,
+		fileAttribute: "client_key_file",
+		file:          data.ClientKeyFile,
+		fileEnvVar:    SYNOLOGY_CLIENT_KEY_FILE_ENV_VAR,
+	}.load(diags)
+	switch {
+	case len(clientCert) > 0 && len(clientKey) > 0:
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			diags.Append(diag.NewAttributeErrorDiagnostic(
+				clientCertPath,
+				"invalid provider configuration",
+				fmt.Sprintf("client certificate can't be loaded: %s", err)))
+			break
+		}
+		options = append(options, client.WithClientCertificate(certificate))
+	case len(clientCert) > 0:
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			clientKeyPath,
+			"invalid provider configuration",
+			"client key must be set along with client certificate"))
+	case len(clientKey) > 0:
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			clientCertPath,
+			"invalid provider configuration",
+			"client certificate must be set along with client key"))
+	}
+
+	fingerprint := data.CertFingerprint.ValueString()
+	if v := os.Getenv(SYNOLOGY_CERT_FINGERPRINT_ENV_VAR); v != "" {
+		fingerprint = v
+	}
+	if fingerprint != "" {
+		v, err := client.ParseCertificateFingerprint(fingerprint)
+		if err != nil {
+			diags.Append(diag.NewAttributeErrorDiagnostic(
+				path.Root("cert_fingerprint"),
+				"invalid provider configuration",
+				err.Error()))
+		} else {
+			options = append(options, client.WithCertificateFingerprint(v))
+		}
+	}
+
+	serverName := data.TLSServerName.ValueString()
+	if v := os.Getenv(SYNOLOGY_TLS_SERVER_NAME_ENV_VAR); v != "" {
+		serverName = v
+	}
+	if serverName != "" {
+		options = append(options, client.WithServerName(serverName))
+	}
+
+	return options
+}
+
+// pemSource describes PEM-encoded value set either inline or as a path to file.
+type pemSource struct {
+	attribute     string
+	value         types.String
+	envVar        string
+	fileAttribute string
+	file          types.String
+	fileEnvVar    string
+}
+
+// load returns PEM content along with the path of attribute it came from.
+// Inline content wins if both are set, environment variables take precedence over attributes.
+func (s pemSource) load(diags *diag.Diagnostics) ([]byte, path.Path) {
+	value := s.value.ValueString()
+	if v := os.Getenv(s.envVar); v != "" {
+		value = v
+	}
+	if value != "" {
+		return []byte(value), path.Root(s.attribute)
+	}
+
+	file := s.file.ValueString()
+	if v := os.Getenv(s.fileEnvVar); v != "" {
+		file = v
+	}
+	if file == "" {
+		return nil, path.Root(s.attribute)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		diags.Append(diag.NewAttributeErrorDiagnostic(
+			path.Root(s.fileAttribute),
+			"invalid provider configuration",
+			err.Error()))
+		return nil, path.Root(s.fileAttribute)
+	}
+
+	return content, path.Root(s.fileAttribute)
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"io"
 	"log"
 
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/maksym-nazarenko/terraform-provider-synology/internal/provider"
 )
@@ -21,7 +23,15 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(), opts)
+	synologyProvider := provider.New()()
+	err := providerserver.Serve(context.Background(), func() tfprovider.Provider { return synologyProvider }, opts)
+
+	// terminate remote sessions once Terraform stops the provider
+	if closer, ok := synologyProvider.(io.Closer); ok {
+		if closeErr := closer.Close(); closeErr != nil {
+			log.Print(closeErr.Error())
+		}
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
@@ -0,0 +1,28 @@
+package auth
+
+// Error codes returned by SYNO.API.Auth API.
+const (
+	ErrorIncorrectCredentials      = 400
+	ErrorAccountDisabled           = 401
+	ErrorPermissionDenied          = 402
+	ErrorOTPRequired               = 403
+	ErrorOTPFailed                 = 404
+	ErrorOTPEnforced               = 406
+	ErrorIPBlocked                 = 407
+	ErrorPasswordExpiredCantChange = 408
+	ErrorPasswordExpired           = 409
+	ErrorPasswordMustChange        = 410
+)
+
+var commonErrors map[int]string = map[int]string{
+	ErrorIncorrectCredentials:      "No such account or incorrect password",
+	ErrorAccountDisabled:           "Disabled account",
+	ErrorPermissionDenied:          "Denied permission",
+	ErrorOTPRequired:               "2-factor authentication code required",
+	ErrorOTPFailed:                 "Failed to authenticate 2-factor authentication code",
+	ErrorOTPEnforced:               "Enforce to authenticate with 2-factor authentication code",
+	ErrorIPBlocked:                 "Blocked IP source",
+	ErrorPasswordExpiredCantChange: "Expired password cannot change",
+	ErrorPasswordExpired:           "Expired password",
+	ErrorPasswordMustChange:        "Password must be changed",
+}
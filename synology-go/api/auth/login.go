@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+type LoginRequest struct {
+	baseAuthRequest
+
+	account           string `synology:"account"`
+	passwd            string `synology:"passwd"`
+	session           string `synology:"session"`
+	format            string `synology:"format"`
+	otpCode           string `synology:"otp_code,omitempty"`
+	enableDeviceToken string `synology:"enable_device_token"`
+	deviceName        string `synology:"device_name,omitempty"`
+	deviceID          string `synology:"device_id,omitempty"`
+	enableSynoToken   string `synology:"enable_syno_token"`
+}
+
+type LoginResponse struct {
+	baseAuthResponse
+
+	SID string `synology:"sid"`
+	// SynoToken is a CSRF token, returned if it was requested during login.
+	SynoToken string `synology:"synotoken"`
+	// DID is a trusted device token, returned if it was requested during login.
+	DID string `synology:"did"`
+}
+
+var _ api.Request = (*LoginRequest)(nil)
+var _ api.HTTPMethodProvider = (*LoginRequest)(nil)
+
+func NewLoginRequest(version int) *LoginRequest {
+	return &LoginRequest{
+		baseAuthRequest: baseAuthRequest{
+			Version:    version,
+			APIName:    "SYNO.API.Auth",
+			APIMethod:  "login",
+			minVersion: 3,
+		},
+		format:            "cookie",
+		enableDeviceToken: "no",
+		enableSynoToken:   "no",
+	}
+}
+
+// HTTPMethod returns POST to keep credentials out of query string.
+func (r LoginRequest) HTTPMethod() string {
+	return http.MethodPost
+}
+
+func (r *LoginRequest) WithAccount(value string) *LoginRequest {
+	r.account = value
+	return r
+}
+
+func (r *LoginRequest) WithPassword(value string) *LoginRequest {
+	r.passwd = value
+	return r
+}
+
+func (r *LoginRequest) WithSession(value string) *LoginRequest {
+	r.session = value
+	return r
+}
+
+// WithFormat sets the way session ID is returned: "cookie" or "sid".
+func (r *LoginRequest) WithFormat(value string) *LoginRequest {
+	r.format = value
+	return r
+}
+
+// WithSynoToken requests CSRF token required by some APIs in X-SYNO-TOKEN header.
+func (r *LoginRequest) WithSynoToken(value bool) *LoginRequest {
+	r.enableSynoToken = "no"
+	if value {
+		r.enableSynoToken = "yes"
+	}
+	return r
+}
+
+func (r *LoginRequest) WithOTPCode(value string) *LoginRequest {
+	r.otpCode = value
+	return r
+}
+
+// WithDeviceToken requests a trusted device token for device with the given name.
+// Subsequent logins with the token returned in LoginResponse.DID skip 2-step verification.
+func (r *LoginRequest) WithDeviceToken(deviceName string) *LoginRequest {
+	r.enableDeviceToken = "yes"
+	r.deviceName = deviceName
+	return r
+}
+
+func (r *LoginRequest) WithDeviceID(value string) *LoginRequest {
+	r.deviceID = value
+	return r
+}
+
+func (r LoginResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
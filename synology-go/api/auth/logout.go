@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+type LogoutRequest struct {
+	baseAuthRequest
+
+	session string `synology:"session"`
+}
+
+type LogoutResponse struct {
+	baseAuthResponse
+}
+
+var _ api.Request = (*LogoutRequest)(nil)
+
+func NewLogoutRequest(version int) *LogoutRequest {
+	return &LogoutRequest{
+		baseAuthRequest: baseAuthRequest{
+			Version:    version,
+			APIName:    "SYNO.API.Auth",
+			APIMethod:  "logout",
+			minVersion: 1,
+		},
+	}
+}
+
+func (r *LogoutRequest) WithSession(value string) *LogoutRequest {
+	r.session = value
+	return r
+}
+
+func (r LogoutResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+
+type baseAuthRequest struct {
+	Version   int    `synology:"version"`
+	APIName   string `synology:"api"`
+	APIMethod string `synology:"method"`
+
+	// minVersion is the lowest API version the request is implemented for.
+	// Version field is treated as the highest one.
+	minVersion int
+}
+
+func (b baseAuthRequest) VersionRange() (int, int) {
+	minVersion := b.minVersion
+	if minVersion == 0 || minVersion > b.Version {
+		minVersion = b.Version
+	}
+
+	return minVersion, b.Version
+}
+
+type baseAuthResponse struct {
+	synologyError api.SynologyError
+}
+
+func (b *baseAuthResponse) SetError(e api.SynologyError) {
+	b.synologyError = e
+}
+
+func (b baseAuthResponse) Success() bool {
+	return b.synologyError.Code == 0
+}
+
+func (b *baseAuthResponse) GetError() api.SynologyError {
+	return b.synologyError
+}
@@ -2,10 +2,22 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
 
+// Sentinel errors to check API failures with errors.Is regardless of concrete error code.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSessionExpired   = errors.New("session expired")
+	// ErrBusy means that remote instance could not handle the request at the moment,
+	// so it is safe to retry it later.
+	ErrBusy = errors.New("remote instance is busy")
+)
+
 // GlobalErrors holds mapping of global errors not related to particular API endpoint.
 var GlobalErrors ErrorSummary = ErrorSummary{
 	100: "Unknown error",
@@ -19,12 +31,27 @@ var GlobalErrors ErrorSummary = ErrorSummary{
 	119: "SID not found",
 }
 
+// GlobalSentinelErrors maps global error codes to sentinel errors.
+var GlobalSentinelErrors SentinelErrors = SentinelErrors{
+	105: ErrPermissionDenied,
+	106: ErrSessionExpired,
+	107: ErrSessionExpired,
+	119: ErrSessionExpired,
+}
+
 // ErrorDescriber defines interface to report all known errors to particular object.
 type ErrorDescriber interface {
 	// ErrorSummaries returns information about all known errors.
 	ErrorSummaries() []ErrorSummary
 }
 
+// SentinelErrorProvider is implemented by responses which map API-specific error codes to sentinel errors.
+// Error codes overlap between API families, so the mapping must be defined per response.
+type SentinelErrorProvider interface {
+	// SentinelErrors returns mapping of error codes to sentinel errors.
+	SentinelErrors() SentinelErrors
+}
+
 // SynologyError defines a structure for error object returned by Synology API.
 // It is a high-level error for a particular API family.
 type SynologyError struct {
@@ -32,6 +59,9 @@ type SynologyError struct {
 	Summary string
 	// Errors is a collection of detailed errors for a concrete API request.
 	Errors []ErrorItem
+	// Sentinel is one of Err* values matching the code, if any.
+	// It allows to check the error with errors.Is.
+	Sentinel error
 }
 
 // ErrorItem defines detailed request error.
@@ -47,6 +77,9 @@ type ErrorSummary map[int]string
 // ErrorFields defines extra fields for particular detailed error.
 type ErrorFields map[string]interface{}
 
+// SentinelErrors is a mapping of error codes to sentinel errors.
+type SentinelErrors map[int]error
+
 // Error satisfies error interface for SynologyError type.
 func (se SynologyError) Error() string {
 	buf := strings.Builder{}
@@ -69,6 +102,11 @@ func (se SynologyError) Error() string {
 	return buf.String()
 }
 
+// Unwrap returns sentinel error matching the code, if any.
+func (se SynologyError) Unwrap() error {
+	return se.Sentinel
+}
+
 // DescribeError translates error code to human-readable summary text.
 // It accepts error code and number of summary maps to look in.
 // First summary with this code wins.
@@ -16,10 +16,10 @@ type CreateFolderResponse struct {
 	baseFileStationResponse
 
 	Folders []struct {
-		Path  string
-		Name  string
-		IsDir bool
-	}
+		Path  string `synology:"path"`
+		Name  string `synology:"name"`
+		IsDir bool   `synology:"isdir"`
+	} `synology:"folders"`
 }
 
 var _ api.Request = (*CreateFolderRequest)(nil)
@@ -27,9 +27,10 @@ var _ api.Request = (*CreateFolderRequest)(nil)
 func NewCreateFolderRequest(version int) *CreateFolderRequest {
 	return &CreateFolderRequest{
 		baseFileStationRequest: baseFileStationRequest{
-			Version:   version,
-			APIName:   "SYNO.FileStation.CreateFolder",
-			APIMethod: "create",
+			Version:    version,
+			APIName:    "SYNO.FileStation.CreateFolder",
+			APIMethod:  "create",
+			minVersion: 2,
 		},
 	}
 }
@@ -49,6 +50,11 @@ func (r *CreateFolderRequest) WithForceParent(value bool) *CreateFolderRequest {
 	return r
 }
 
+// Idempotent reports false, since repeated request fails when the folder already exists.
+func (r CreateFolderRequest) Idempotent() bool {
+	return false
+}
+
 func (r CreateFolderResponse) ErrorSummaries() []api.ErrorSummary {
 	return []api.ErrorSummary{
 		{
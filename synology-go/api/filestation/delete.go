@@ -0,0 +1,150 @@
+package filestation
+
+import (
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// DeleteStartRequest starts non-blocking deletion of files and folders.
+// The task is polled with DeleteStatusRequest and cancelled with DeleteStopRequest.
+type DeleteStartRequest struct {
+	baseFileStationRequest
+
+	paths            []string `synology:"path"`
+	recursive        bool     `synology:"recursive"`
+	accurateProgress bool     `synology:"accurate_progress"`
+}
+
+type DeleteStartResponse struct {
+	baseFileStationResponse
+
+	ID string `synology:"taskid"`
+}
+
+type DeleteStatusRequest struct {
+	baseFileStationRequest
+
+	taskID string `synology:"taskid"`
+}
+
+type DeleteStatusResponse struct {
+	baseFileStationResponse
+
+	IsFinished     bool    `synology:"finished"`
+	ProgressRatio  float64 `synology:"progress"`
+	Path           string  `synology:"path"`
+	ProcessingPath string  `synology:"processing_path"`
+	ProcessedNum   int     `synology:"processed_num"`
+	Total          int     `synology:"total"`
+}
+
+type DeleteStopRequest struct {
+	baseFileStationRequest
+
+	taskID string `synology:"taskid"`
+}
+
+type DeleteStopResponse struct {
+	baseFileStationResponse
+}
+
+var (
+	_ api.Request = (*DeleteStartRequest)(nil)
+	_ api.Request = (*DeleteStatusRequest)(nil)
+	_ api.Request = (*DeleteStopRequest)(nil)
+)
+
+func NewDeleteStartRequest(version int) *DeleteStartRequest {
+	return &DeleteStartRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.Delete",
+			APIMethod:  "start",
+			minVersion: 2,
+		},
+		recursive:        true,
+		accurateProgress: true,
+	}
+}
+
+func (r *DeleteStartRequest) WithPath(value string) *DeleteStartRequest {
+	r.paths = append(r.paths, value)
+	return r
+}
+
+// WithRecursive sets whether content of folders is deleted as well, it is true by default.
+// Deletion of non-empty folder fails otherwise.
+func (r *DeleteStartRequest) WithRecursive(value bool) *DeleteStartRequest {
+	r.recursive = value
+	return r
+}
+
+// WithAccurateProgress sets whether progress is calculated precisely, it is true by default.
+func (r *DeleteStartRequest) WithAccurateProgress(value bool) *DeleteStartRequest {
+	r.accurateProgress = value
+	return r
+}
+
+// Idempotent reports false, since repeated request fails when the paths are already deleted.
+func (r DeleteStartRequest) Idempotent() bool {
+	return false
+}
+
+// TaskID returns ID of started deletion task.
+func (r *DeleteStartResponse) TaskID() string {
+	return r.ID
+}
+
+func NewDeleteStatusRequest(version int, taskID string) *DeleteStatusRequest {
+	return &DeleteStatusRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.Delete",
+			APIMethod:  "status",
+			minVersion: 2,
+		},
+		taskID: taskID,
+	}
+}
+
+// Finished reports whether deletion is complete.
+func (r *DeleteStatusResponse) Finished() bool {
+	return r.IsFinished
+}
+
+// Progress returns completion ratio of deletion.
+func (r *DeleteStatusResponse) Progress() float64 {
+	return r.ProgressRatio
+}
+
+func NewDeleteStopRequest(version int, taskID string) *DeleteStopRequest {
+	return &DeleteStopRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.Delete",
+			APIMethod:  "stop",
+			minVersion: 2,
+		},
+		taskID: taskID,
+	}
+}
+
+func (r DeleteStartResponse) ErrorSummaries() []api.ErrorSummary {
+	return deleteErrorSummaries()
+}
+
+func (r DeleteStatusResponse) ErrorSummaries() []api.ErrorSummary {
+	return deleteErrorSummaries()
+}
+
+func (r DeleteStopResponse) ErrorSummaries() []api.ErrorSummary {
+	return deleteErrorSummaries()
+}
+
+func deleteErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{
+		{
+			900: "Failed to delete file(s)/folder(s). More information in <errors> object.",
+		},
+		commonErrors,
+	}
+}
@@ -0,0 +1,105 @@
+package filestation
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// ErrContentTooLarge is returned when downloaded content exceeds the limit of DownloadResponse.
+var ErrContentTooLarge = errors.New("content is too large")
+
+// DownloadRequest downloads files or folders.
+// A single file is sent as is, while folders and multiple paths are sent as a zip archive.
+type DownloadRequest struct {
+	baseFileStationRequest
+
+	paths []string `synology:"path"`
+	mode  string   `synology:"mode"`
+}
+
+// DownloadResponse streams downloaded content into Writer.
+type DownloadResponse struct {
+	baseFileStationResponse
+
+	// Writer receives downloaded content, it must be set before the request is sent.
+	Writer io.Writer
+	// Limit is the maximal number of bytes written to Writer, zero means no limit.
+	// Larger content fails the request with ErrContentTooLarge.
+	Limit int64
+
+	// ContentType is the media type of the content, e.g. "application/zip" for an archive.
+	ContentType string
+	// FileName is the file name suggested by remote instance, e.g. "folder.zip" for a folder.
+	FileName string
+	// Size is the number of bytes written to Writer.
+	Size int64
+}
+
+var (
+	_ api.Request     = (*DownloadRequest)(nil)
+	_ api.RawResponse = (*DownloadResponse)(nil)
+)
+
+func NewDownloadRequest(version int) *DownloadRequest {
+	return &DownloadRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.Download",
+			APIMethod:  "download",
+			minVersion: 2,
+		},
+		mode: "download",
+	}
+}
+
+// WithPath adds path to download, the content is zipped if more than one path is added.
+func (r *DownloadRequest) WithPath(value string) *DownloadRequest {
+	r.paths = append(r.paths, value)
+	return r
+}
+
+// ReadBody copies downloaded content into Writer, respecting Limit.
+func (r *DownloadResponse) ReadBody(header http.Header, body io.Reader) error {
+	r.ContentType, _, _ = mime.ParseMediaType(header.Get("Content-Type"))
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		r.FileName = params["filename"]
+	}
+	if r.Writer == nil {
+		return errors.New("writer of download response is not set")
+	}
+
+	if r.Limit <= 0 {
+		n, err := io.Copy(r.Writer, body)
+		r.Size = n
+		return err
+	}
+
+	// fail early if the size is known in advance
+	if size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil && size > r.Limit {
+		return ErrContentTooLarge
+	}
+	n, err := io.Copy(r.Writer, io.LimitReader(body, r.Limit))
+	r.Size = n
+	if err != nil {
+		return err
+	}
+	if n, _ := io.ReadFull(body, make([]byte, 1)); n > 0 {
+		return ErrContentTooLarge
+	}
+
+	return nil
+}
+
+// Zipped reports whether the content is a zip archive, i.e. a folder or multiple paths were downloaded.
+func (r DownloadResponse) Zipped() bool {
+	return r.ContentType == "application/zip"
+}
+
+func (r DownloadResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
@@ -1,5 +1,7 @@
 package filestation
 
+import "github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+
 var commonErrors map[int]string = map[int]string{
 	400: "Invalid parameter of file operation",
 	401: "Unknown error of file operation",
@@ -22,6 +24,16 @@ var commonErrors map[int]string = map[int]string{
 	418: "Illegal name or path",
 	419: "Illegal file name",
 	420: "Illegal file name on FAT file system",
+	// 421 is not mapped to ErrBusy: the operation may be partially processed, so it must not be replayed blindly
 	421: "Device or resource busy",
 	599: "No such task of the file operation",
 }
+
+var sentinelErrors api.SentinelErrors = api.SentinelErrors{
+	402: api.ErrBusy,
+	407: api.ErrPermissionDenied,
+	408: api.ErrNotFound,
+	414: api.ErrAlreadyExists,
+	// upload of existing file without overwrite parameter
+	1805: api.ErrAlreadyExists,
+}
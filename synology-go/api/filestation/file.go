@@ -0,0 +1,109 @@
+package filestation
+
+import "time"
+
+// Additional is a name of optional file attribute requested from list and getinfo methods.
+type Additional string
+
+const (
+	AdditionalRealPath       Additional = "real_path"
+	AdditionalSize           Additional = "size"
+	AdditionalOwner          Additional = "owner"
+	AdditionalTime           Additional = "time"
+	AdditionalPerm           Additional = "perm"
+	AdditionalMountPointType Additional = "mount_point_type"
+	AdditionalType           Additional = "type"
+	// AdditionalVolumeStatus is supported by list_share method only.
+	AdditionalVolumeStatus Additional = "volume_status"
+)
+
+// SortBy is a file attribute to sort list by.
+type SortBy string
+
+const (
+	SortByName   SortBy = "name"
+	SortBySize   SortBy = "size"
+	SortByUser   SortBy = "user"
+	SortByGroup  SortBy = "group"
+	SortByMTime  SortBy = "mtime"
+	SortByATime  SortBy = "atime"
+	SortByCTime  SortBy = "ctime"
+	SortByCRTime SortBy = "crtime"
+	SortByPosix  SortBy = "posix"
+	// SortByType is supported by list method only.
+	SortByType SortBy = "type"
+)
+
+// SortDirection is an order of sorted list.
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// File describes file or folder reported by FileStation APIs.
+type File struct {
+	Path  string `synology:"path"`
+	Name  string `synology:"name"`
+	IsDir bool   `synology:"isdir"`
+	// Code is a non-zero error code of the path which could not be processed,
+	// e.g. 408 for a missing one in getinfo response.
+	Code int `synology:"code"`
+	// Additional holds attributes requested with Additional values, it is nil if none are requested.
+	Additional *FileAdditional `synology:"additional"`
+}
+
+// FileAdditional holds optional attributes of file or folder.
+type FileAdditional struct {
+	RealPath       string        `synology:"real_path"`
+	Size           int64         `synology:"size"`
+	Owner          FileOwner     `synology:"owner"`
+	Time           FileTime      `synology:"time"`
+	Perm           FilePerm      `synology:"perm"`
+	MountPointType string        `synology:"mount_point_type"`
+	Type           string        `synology:"type"`
+	VolumeStatus   *VolumeStatus `synology:"volume_status"`
+}
+
+// FileOwner describes owner of file or folder.
+type FileOwner struct {
+	User  string `synology:"user"`
+	Group string `synology:"group"`
+	UID   int    `synology:"uid"`
+	GID   int    `synology:"gid"`
+}
+
+// FileTime holds timestamps of file or folder.
+type FileTime struct {
+	ATime  time.Time `synology:"atime"`
+	MTime  time.Time `synology:"mtime"`
+	CTime  time.Time `synology:"ctime"`
+	CRTime time.Time `synology:"crtime"`
+}
+
+// FilePerm describes permissions of file or folder.
+type FilePerm struct {
+	// ShareRight is a permission of shared folder, "RW", "RO" or "-", reported by list_share only.
+	ShareRight string `synology:"share_right"`
+	// Posix holds POSIX permission in octal digits, e.g. 755.
+	Posix     int     `synology:"posix"`
+	IsACLMode bool    `synology:"is_acl_mode"`
+	ACL       FileACL `synology:"acl"`
+}
+
+// FileACL describes ACL permissions of current user.
+type FileACL struct {
+	Append bool `synology:"append"`
+	Del    bool `synology:"del"`
+	Exec   bool `synology:"exec"`
+	Read   bool `synology:"read"`
+	Write  bool `synology:"write"`
+}
+
+// VolumeStatus describes volume of shared folder.
+type VolumeStatus struct {
+	FreeSpace  int64 `synology:"freespace"`
+	TotalSpace int64 `synology:"totalspace"`
+	ReadOnly   bool  `synology:"readonly"`
+}
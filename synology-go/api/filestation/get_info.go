@@ -0,0 +1,47 @@
+package filestation
+
+import (
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+type GetInfoRequest struct {
+	baseFileStationRequest
+
+	paths      []string     `synology:"path"`
+	additional []Additional `synology:"additional,omitempty"`
+}
+
+type GetInfoResponse struct {
+	baseFileStationResponse
+
+	// Files holds an entry per requested path, missing ones have non-zero Code.
+	Files []File `synology:"files"`
+}
+
+var _ api.Request = (*GetInfoRequest)(nil)
+
+func NewGetInfoRequest(version int) *GetInfoRequest {
+	return &GetInfoRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.List",
+			APIMethod:  "getinfo",
+			minVersion: 2,
+		},
+	}
+}
+
+// WithPath adds path to get information about, files of response follow order of added paths.
+func (r *GetInfoRequest) WithPath(value string) *GetInfoRequest {
+	r.paths = append(r.paths, value)
+	return r
+}
+
+func (r *GetInfoRequest) WithAdditional(values ...Additional) *GetInfoRequest {
+	r.additional = append(r.additional, values...)
+	return r
+}
+
+func (r GetInfoResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
@@ -11,10 +11,11 @@ type FileStationInfoRequest struct {
 type FileStationInfoResponse struct {
 	baseFileStationResponse
 
-	IsManager              bool
-	SupportVirtualProtocol string
-	Supportsharing         bool
-	Hostname               string
+	IsManager              bool   `synology:"is_manager"`
+	SupportVirtualProtocol string `synology:"support_virtual_protocol"`
+	SupportSharing         bool   `synology:"support_sharing"`
+	Hostname               string `synology:"hostname"`
+	UID                    int    `synology:"uid"`
 }
 
 var _ api.Request = (*FileStationInfoRequest)(nil)
@@ -22,9 +23,10 @@ var _ api.Request = (*FileStationInfoRequest)(nil)
 func NewFileStationInfoRequest(version int) *FileStationInfoRequest {
 	return &FileStationInfoRequest{
 		baseFileStationRequest: baseFileStationRequest{
-			Version:   version,
-			APIName:   "SYNO.FileStation.Info",
-			APIMethod: "get",
+			Version:    version,
+			APIName:    "SYNO.FileStation.Info",
+			APIMethod:  "get",
+			minVersion: 2,
 		},
 	}
 }
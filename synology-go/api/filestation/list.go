@@ -0,0 +1,101 @@
+package filestation
+
+import (
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// FileType is a type of files to list.
+type FileType string
+
+const (
+	FileTypeFile FileType = "file"
+	FileTypeDir  FileType = "dir"
+	FileTypeAll  FileType = "all"
+)
+
+type ListRequest struct {
+	baseFileStationRequest
+
+	folderPath    string        `synology:"folder_path"`
+	offset        int           `synology:"offset"`
+	limit         int           `synology:"limit"`
+	sortBy        SortBy        `synology:"sort_by,omitempty"`
+	sortDirection SortDirection `synology:"sort_direction,omitempty"`
+	patterns      []string      `synology:"pattern,omitempty,comma"`
+	fileType      FileType      `synology:"filetype,omitempty"`
+	additional    []Additional  `synology:"additional,omitempty"`
+}
+
+type ListResponse struct {
+	baseFileStationResponse
+
+	Total  int    `synology:"total"`
+	Offset int    `synology:"offset"`
+	Files  []File `synology:"files"`
+}
+
+var _ api.Request = (*ListRequest)(nil)
+
+func NewListRequest(version int, folderPath string) *ListRequest {
+	return &ListRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.List",
+			APIMethod:  "list",
+			minVersion: 2,
+		},
+		folderPath: folderPath,
+	}
+}
+
+func (r *ListRequest) WithOffset(value int) *ListRequest {
+	r.offset = value
+	return r
+}
+
+// WithLimit sets maximum number of files returned, 0 means all of them.
+func (r *ListRequest) WithLimit(value int) *ListRequest {
+	r.limit = value
+	return r
+}
+
+func (r *ListRequest) WithSortBy(value SortBy) *ListRequest {
+	r.sortBy = value
+	return r
+}
+
+func (r *ListRequest) WithSortDirection(value SortDirection) *ListRequest {
+	r.sortDirection = value
+	return r
+}
+
+// WithPattern adds glob pattern, e.g. "*.txt", files matching any pattern are listed.
+// Patterns are sent as a comma-separated list, so a pattern can't contain commas.
+func (r *ListRequest) WithPattern(value string) *ListRequest {
+	r.patterns = append(r.patterns, value)
+	return r
+}
+
+func (r *ListRequest) WithFileType(value FileType) *ListRequest {
+	r.fileType = value
+	return r
+}
+
+func (r *ListRequest) WithAdditional(values ...Additional) *ListRequest {
+	r.additional = append(r.additional, values...)
+	return r
+}
+
+// PageItems returns files of the page.
+func (r *ListResponse) PageItems() []File {
+	return r.Files
+}
+
+// PageTotal returns total number of files matching the request.
+func (r *ListResponse) PageTotal() int {
+	return r.Total
+}
+
+func (r ListResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
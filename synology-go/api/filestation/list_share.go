@@ -0,0 +1,83 @@
+package filestation
+
+import (
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+type ListShareRequest struct {
+	baseFileStationRequest
+
+	offset        int           `synology:"offset"`
+	limit         int           `synology:"limit"`
+	sortBy        SortBy        `synology:"sort_by,omitempty"`
+	sortDirection SortDirection `synology:"sort_direction,omitempty"`
+	onlyWritable  bool          `synology:"onlywritable"`
+	additional    []Additional  `synology:"additional,omitempty"`
+}
+
+type ListShareResponse struct {
+	baseFileStationResponse
+
+	Total  int    `synology:"total"`
+	Offset int    `synology:"offset"`
+	Shares []File `synology:"shares"`
+}
+
+var _ api.Request = (*ListShareRequest)(nil)
+
+func NewListShareRequest(version int) *ListShareRequest {
+	return &ListShareRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.List",
+			APIMethod:  "list_share",
+			minVersion: 2,
+		},
+	}
+}
+
+func (r *ListShareRequest) WithOffset(value int) *ListShareRequest {
+	r.offset = value
+	return r
+}
+
+// WithLimit sets maximum number of shares returned, 0 means all of them.
+func (r *ListShareRequest) WithLimit(value int) *ListShareRequest {
+	r.limit = value
+	return r
+}
+
+func (r *ListShareRequest) WithSortBy(value SortBy) *ListShareRequest {
+	r.sortBy = value
+	return r
+}
+
+func (r *ListShareRequest) WithSortDirection(value SortDirection) *ListShareRequest {
+	r.sortDirection = value
+	return r
+}
+
+// WithOnlyWritable limits the list to shares current user can write to.
+func (r *ListShareRequest) WithOnlyWritable(value bool) *ListShareRequest {
+	r.onlyWritable = value
+	return r
+}
+
+func (r *ListShareRequest) WithAdditional(values ...Additional) *ListShareRequest {
+	r.additional = append(r.additional, values...)
+	return r
+}
+
+// PageItems returns shares of the page.
+func (r *ListShareResponse) PageItems() []File {
+	return r.Shares
+}
+
+// PageTotal returns total number of shares.
+func (r *ListShareResponse) PageTotal() int {
+	return r.Total
+}
+
+func (r ListShareResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{commonErrors}
+}
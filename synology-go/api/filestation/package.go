@@ -6,6 +6,19 @@ type baseFileStationRequest struct {
 	Version   int    `synology:"version"`
 	APIName   string `synology:"api"`
 	APIMethod string `synology:"method"`
+
+	// minVersion is the lowest API version the request is implemented for.
+	// Version field is treated as the highest one.
+	minVersion int
+}
+
+func (b baseFileStationRequest) VersionRange() (int, int) {
+	minVersion := b.minVersion
+	if minVersion == 0 || minVersion > b.Version {
+		minVersion = b.Version
+	}
+
+	return minVersion, b.Version
 }
 
 type baseFileStationResponse struct {
@@ -23,3 +36,7 @@ func (b baseFileStationResponse) Success() bool {
 func (b *baseFileStationResponse) GetError() api.SynologyError {
 	return b.synologyError
 }
+
+func (b baseFileStationResponse) SentinelErrors() api.SentinelErrors {
+	return sentinelErrors
+}
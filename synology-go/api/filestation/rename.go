@@ -7,20 +7,14 @@ import (
 type FileStationRenameRequest struct {
 	baseFileStationRequest
 
-	name string
-	path string
-}
-
-type File struct {
-	Path  string
-	Name  string
-	IsDir bool
+	paths []string `synology:"path"`
+	names []string `synology:"name"`
 }
 
 type FileStationRenameResponse struct {
 	baseFileStationResponse
 
-	Files []File
+	Files []File `synology:"files"`
 }
 
 var _ api.Request = (*FileStationRenameRequest)(nil)
@@ -28,23 +22,29 @@ var _ api.Request = (*FileStationRenameRequest)(nil)
 func NewFileStationRenameRequest(version int) *FileStationRenameRequest {
 	return &FileStationRenameRequest{
 		baseFileStationRequest: baseFileStationRequest{
-			Version:   version,
-			APIName:   "SYNO.FileStation.Rename",
-			APIMethod: "rename",
+			Version:    version,
+			APIName:    "SYNO.FileStation.Rename",
+			APIMethod:  "rename",
+			minVersion: 2,
 		},
 	}
 }
 
 func (r *FileStationRenameRequest) WithName(value string) *FileStationRenameRequest {
-	r.name = value
+	r.names = append(r.names, value)
 	return r
 }
 
 func (r *FileStationRenameRequest) WithPath(value string) *FileStationRenameRequest {
-	r.path = value
+	r.paths = append(r.paths, value)
 	return r
 }
 
+// Idempotent reports false, since repeated request fails when the source is already renamed.
+func (r FileStationRenameRequest) Idempotent() bool {
+	return false
+}
+
 func (r FileStationRenameResponse) ErrorSummaries() []api.ErrorSummary {
 	return []api.ErrorSummary{
 		{
@@ -16,9 +16,10 @@ var _ api.Request = (*__TEMPLATE_TYPE_PLACEHOLDER__Request)(nil)
 func New__TEMPLATE_TYPE_PLACEHOLDER__Request(version int) *__TEMPLATE_TYPE_PLACEHOLDER__Request {
 	return &__TEMPLATE_TYPE_PLACEHOLDER__Request{
 		baseFileStationRequest: baseFileStationRequest{
-			Version:   version,
-			APIName:   "SYNO.FileStation.__TEMPLATE_TYPE_PLACEHOLDER__",
-			APIMethod: "_",
+			Version:    version,
+			APIName:    "SYNO.FileStation.__TEMPLATE_TYPE_PLACEHOLDER__",
+			APIMethod:  "_",
+			minVersion: 1,
 		},
 	}
 }
@@ -0,0 +1,106 @@
+package filestation
+
+import (
+	"io"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// UploadRequest uploads a file into a folder, the content is streamed in multipart body.
+type UploadRequest struct {
+	baseFileStationRequest
+
+	path          string `synology:"path"`
+	createParents bool   `synology:"create_parents"`
+	overwrite     *bool  `synology:"overwrite"`
+	mtime         int64  `synology:"mtime,omitempty"`
+	crtime        int64  `synology:"crtime,omitempty"`
+	atime         int64  `synology:"atime,omitempty"`
+
+	fileName string
+	content  io.Reader
+}
+
+type UploadResponse struct {
+	baseFileStationResponse
+
+	// Skipped is set if the file already exists and overwrite is disabled.
+	Skipped bool   `synology:"blSkip"`
+	File    string `synology:"file"`
+}
+
+var (
+	_ api.Request          = (*UploadRequest)(nil)
+	_ api.MultipartRequest = (*UploadRequest)(nil)
+)
+
+// NewUploadRequest creates request uploading file with name and content into folder.
+// Content implementing io.Seeker, e.g. *os.File or *bytes.Reader, is sent with Content-Length
+// required by DSM and can be replayed after re-authentication.
+func NewUploadRequest(version int, folderPath, fileName string, content io.Reader) *UploadRequest {
+	return &UploadRequest{
+		baseFileStationRequest: baseFileStationRequest{
+			Version:    version,
+			APIName:    "SYNO.FileStation.Upload",
+			APIMethod:  "upload",
+			minVersion: 2,
+		},
+		path:     folderPath,
+		fileName: fileName,
+		content:  content,
+	}
+}
+
+func (r *UploadRequest) WithCreateParents(value bool) *UploadRequest {
+	r.createParents = value
+	return r
+}
+
+// WithOverwrite sets whether existing file is overwritten or skipped.
+// Upload of existing file fails with api.ErrAlreadyExists if it is not set.
+func (r *UploadRequest) WithOverwrite(value bool) *UploadRequest {
+	r.overwrite = &value
+	return r
+}
+
+func (r *UploadRequest) WithMTime(value time.Time) *UploadRequest {
+	r.mtime = value.UnixMilli()
+	return r
+}
+
+func (r *UploadRequest) WithCRTime(value time.Time) *UploadRequest {
+	r.crtime = value.UnixMilli()
+	return r
+}
+
+func (r *UploadRequest) WithATime(value time.Time) *UploadRequest {
+	r.atime = value.UnixMilli()
+	return r
+}
+
+// Files returns the uploaded file part.
+func (r UploadRequest) Files() []api.MultipartFile {
+	return []api.MultipartFile{
+		{FieldName: "file", FileName: r.fileName, Content: r.content},
+	}
+}
+
+// Idempotent reports true if existing file is overwritten, since repeated upload has the same result then.
+func (r UploadRequest) Idempotent() bool {
+	return r.overwrite != nil && *r.overwrite
+}
+
+func (r UploadResponse) ErrorSummaries() []api.ErrorSummary {
+	return []api.ErrorSummary{
+		{
+			1800: "There is no Content-Length information in the HTTP header or the received size doesn't match the value of Content-Length information in the HTTP header.",
+			1801: "Wait too long, no date can be received from client (Default maximum wait time is 3600 seconds).",
+			1802: "No filename information in the last part of file content.",
+			1803: "Upload connection is cancelled.",
+			1804: "Failed to upload oversized file to FAT file system.",
+			1805: "Can't overwrite or skip the existing file, if no overwrite parameter is given.",
+		},
+		commonErrors,
+	}
+}
@@ -1,9 +1,67 @@
 // Package api provides types for common objects required during calls to remote Synology instance.
 package api
 
+import (
+	"io"
+	"net/http"
+)
+
 // Request defines a contract for all Request implementations.
 type Request interface{}
 
+// APIPathProvider is implemented by requests which must be sent to a specific CGI path,
+// e.g. "/webapi/query.cgi", regardless of the path advertised by remote instance.
+type APIPathProvider interface {
+	// APIPath returns absolute path of CGI endpoint to send the request to.
+	APIPath() string
+}
+
+// HTTPMethodProvider is implemented by requests which must be sent with specific HTTP method.
+//
+// Parameters of POST requests are sent in application/x-www-form-urlencoded body,
+// only API name, method and version stay in query string.
+// Requests are sent with GET method by default.
+type HTTPMethodProvider interface {
+	// HTTPMethod returns HTTP method to send the request with.
+	HTTPMethod() string
+}
+
+// MultipartRequest is implemented by requests which upload files.
+//
+// Such requests are sent with POST method and multipart/form-data body:
+// parameters go first, followed by file parts streamed from their readers.
+type MultipartRequest interface {
+	// Files returns file parts of the request.
+	Files() []MultipartFile
+}
+
+// MultipartFile describes a single file part of multipart request.
+type MultipartFile struct {
+	// FieldName is the name of form field holding the file.
+	FieldName string
+	// FileName is the name of the file reported to remote instance.
+	FileName string
+	// Content is streamed as file body.
+	// If it implements io.Seeker, the request can be replayed, e.g. after re-authentication.
+	Content io.Reader
+}
+
+// IdempotencyProvider is implemented by requests which report whether they can be safely sent more than once.
+//
+// Client replays requests after transport failures only if they are idempotent.
+// By default, GET requests are considered idempotent, while POST and multipart requests are not.
+type IdempotencyProvider interface {
+	// Idempotent reports whether repeating the request has the same effect as sending it once.
+	Idempotent() bool
+}
+
+// VersionRangeProvider is implemented by requests which support a range of API versions.
+// Client picks the highest version supported by both the request and remote instance.
+type VersionRangeProvider interface {
+	// VersionRange returns minimal and maximal API versions supported by the request.
+	VersionRange() (int, int)
+}
+
 // Response defines an interface for all responses from Synology API.
 type Response interface {
 	ErrorDescriber
@@ -18,6 +76,19 @@ type Response interface {
 	Success() bool
 }
 
+// RawResponse is implemented by responses which consume HTTP response body as is, e.g. file downloads.
+//
+// Client streams the body into ReadBody instead of decoding it as JSON.
+// Remote instance still reports failures of such requests with JSON envelope,
+// which is recognized by Content-Type without Content-Disposition and decoded into response error.
+type RawResponse interface {
+	Response
+
+	// ReadBody consumes body of successful HTTP response, header describes the content.
+	// The body is closed once ReadBody returns, so it must not be retained.
+	ReadBody(header http.Header, body io.Reader) error
+}
+
 // GenericResponse is a concrete Response implementation.
 // It is a generic struct with common to all Synology response fields.
 type GenericResponse struct {
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+const (
+	// defaultAPIPath is used for requests when remote API path is unknown.
+	defaultAPIPath = "/webapi/entry.cgi"
+
+	// apiInfoPath is the only path guaranteed by DSM for SYNO.API.Info API.
+	apiInfoPath = "/webapi/query.cgi"
+)
+
+// APIInfo describes remote API as advertised by SYNO.API.Info.
+type APIInfo struct {
+	Path          string
+	MinVersion    int
+	MaxVersion    int
+	RequestFormat string
+}
+
+// apiInfoRequest queries information about all APIs available on remote instance.
+type apiInfoRequest struct {
+	Version   int    `synology:"version"`
+	APIName   string `synology:"api"`
+	APIMethod string `synology:"method"`
+	Query     string `synology:"query"`
+}
+
+type apiInfoResponse struct {
+	APIs map[string]APIInfo `synology:",remain"`
+
+	synologyError api.SynologyError
+}
+
+func (r apiInfoResponse) ErrorSummaries() []api.ErrorSummary {
+	return nil
+}
+
+func (r *apiInfoResponse) GetError() api.SynologyError {
+	return r.synologyError
+}
+
+func (r *apiInfoResponse) SetError(e api.SynologyError) {
+	r.synologyError = e
+}
+
+func (r apiInfoResponse) Success() bool {
+	return r.synologyError.Code == 0
+}
+
+// apiInfo returns information about remote APIs.
+//
+// The information is requested once and cached for the lifetime of the client.
+func (c *client) apiInfo(ctx context.Context) (map[string]APIInfo, error) {
+	c.apiInfoMu.Lock()
+	defer c.apiInfoMu.Unlock()
+
+	if c.apis != nil {
+		return c.apis, nil
+	}
+
+	request := apiInfoRequest{
+		Version:   1,
+		APIName:   "SYNO.API.Info",
+		APIMethod: "query",
+		Query:     "ALL",
+	}
+	query, err := marshalURL(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := apiInfoResponse{}
+	if err := c.sendThrottled(ctx, apiInfoPath, query, request, &response, c.requestTimeout); err != nil {
+		return nil, err
+	}
+	if !response.Success() {
+		return nil, response.GetError()
+	}
+	c.apis = response.APIs
+	if c.apis == nil {
+		c.apis = map[string]APIInfo{}
+	}
+
+	return c.apis, nil
+}
+
+// resolveAPI detects path and version for the request based on information advertised by remote instance.
+//
+// Version in query is replaced with the negotiated one.
+func (c *client) resolveAPI(ctx context.Context, r api.Request, query url.Values) (string, error) {
+	path := defaultAPIPath
+	if p, ok := r.(api.APIPathProvider); ok {
+		path = p.APIPath()
+	}
+
+	apiName := query.Get("api")
+	if apiName == "" {
+		return path, nil
+	}
+
+	apis, err := c.apiInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("API discovery failed: %w", err)
+	}
+	info, ok := apis[apiName]
+	if !ok {
+		return "", fmt.Errorf("API %s is not available on remote instance", apiName)
+	}
+	if _, ok := r.(api.APIPathProvider); !ok && info.Path != "" {
+		path = "/webapi/" + info.Path
+	}
+
+	minVersion, maxVersion := 0, 0
+	if v, err := strconv.Atoi(query.Get("version")); err == nil {
+		minVersion, maxVersion = v, v
+	}
+	if p, ok := r.(api.VersionRangeProvider); ok {
+		minVersion, maxVersion = p.VersionRange()
+	}
+	version, err := negotiateVersion(info, minVersion, maxVersion)
+	if err != nil {
+		return "", fmt.Errorf("API %s: %w", apiName, err)
+	}
+	query.Set("version", strconv.Itoa(version))
+
+	return path, nil
+}
+
+// negotiateVersion returns the highest version supported by both sides.
+func negotiateVersion(info APIInfo, minVersion, maxVersion int) (int, error) {
+	version := maxVersion
+	if info.MaxVersion < version {
+		version = info.MaxVersion
+	}
+	if version < minVersion || version < info.MinVersion {
+		return 0, fmt.Errorf(
+			"requested versions %d-%d are not supported, remote supports versions %d-%d",
+			minVersion, maxVersion, info.MinVersion, info.MaxVersion,
+		)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,83 @@
+// Package cassette records HTTP interactions with remote Synology instance
+// and replays them later, so client code can be tested against real DSM responses
+// without access to the NAS.
+//
+// Secrets (passwords, one-time codes, session IDs and tokens) are redacted before
+// interactions are stored.
+//
+// Recording:
+//
+//	rec := cassette.NewRecorder()
+//	c, _ := client.New("nas:5001", false, client.WithTransport(rec.Wrap))
+//	// ... run requests
+//	_ = rec.Cassette().Save("testdata/create_folder.json")
+//
+// Replaying:
+//
+//	cas, _ := cassette.Load("testdata/create_folder.json")
+//	c, _ := client.New("nas:5001", false, client.WithTransport(cassette.NewReplayer(cas).Wrap))
+package cassette
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Redacted replaces secret values in recorded interactions.
+const Redacted = "REDACTED"
+
+// Cassette is a sequence of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request with its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request holds the parts of HTTP request used to match it during replay.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Params holds both query and form parameters.
+	Params map[string][]string `json:"params,omitempty"`
+	// Header holds request headers, it is not used for matching.
+	Header map[string][]string `json:"header,omitempty"`
+}
+
+// Response is recorded HTTP response.
+type Response struct {
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header,omitempty"`
+	// Body holds response body if it is a valid UTF-8 text.
+	Body string `json:"body,omitempty"`
+	// BodyBase64 holds binary response body.
+	BodyBase64 []byte `json:"body_base64,omitempty"`
+}
+
+// Load reads cassette from file.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cassette{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Save writes cassette to file.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,126 @@
+package cassette_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/cassette"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/dsmtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := dsmtest.NewServer()
+	defer srv.Close()
+	srv.AddUser("admin", "top-secret-password")
+	srv.AddUser("otp-user", "top-secret-password")
+	srv.SetOTPCode("otp-user", "987654")
+	srv.AddShare("data")
+
+	run := func(t *testing.T, c client.Client) {
+		require.NoError(t, c.Login("otp-user", "top-secret-password", "test", client.WithOTPCode("987654")))
+		response := filestation.CreateFolderResponse{}
+		require.NoError(t, c.Do(filestation.NewCreateFolderRequest(2).WithFolderPath("/data").WithName("a"), &response))
+		require.True(t, response.Success(), response.GetError())
+		require.Len(t, response.Folders, 1)
+		assert.Equal(t, "/data/a", response.Folders[0].Path)
+	}
+
+	recorder := cassette.NewRecorder()
+	c, err := client.New(srv.Host(), true, client.WithTransport(recorder.Wrap), client.WithSessionTransport(client.SessionTransportQuery))
+	require.NoError(t, err)
+	run(t, c)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, recorder.Cassette().Save(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"top-secret-password", "987654"} {
+		assert.NotContains(t, string(content), secret)
+	}
+	assert.Equal(t,
+		strings.Count(string(content), `\"sid\":\"`),
+		strings.Count(string(content), `\"sid\":\"REDACTED\"`),
+		"session ID must be redacted in responses")
+
+	loaded, err := cassette.Load(path)
+	require.NoError(t, err)
+	tokens := 0
+	for _, interaction := range loaded.Interactions {
+		if token, ok := interaction.Request.Header["X-Syno-Token"]; ok {
+			assert.Equal(t, []string{cassette.Redacted}, token, "SynoToken must be redacted in request headers")
+			tokens++
+		}
+	}
+	assert.Positive(t, tokens)
+	replayer := cassette.NewReplayer(loaded)
+	c, err = client.New("nas.invalid:5001", false, client.WithTransport(replayer.Wrap), client.WithSessionTransport(client.SessionTransportQuery))
+	require.NoError(t, err)
+	run(t, c)
+	assert.Equal(t, 0, replayer.Unused())
+
+	err = c.Do(filestation.NewFileStationInfoRequest(2), &filestation.FileStationInfoResponse{})
+	assert.ErrorContains(t, err, "no recorded interaction")
+}
+
+func TestRecorder_sessionCookie(t *testing.T) {
+	srv := dsmtest.NewServer()
+	defer srv.Close()
+	srv.AddUser("admin", "top-secret-password")
+
+	// session IDs are taken from responses before they reach the recorder
+	sessionIDs := []string{}
+	capture := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err == nil {
+				for _, cookie := range resp.Cookies() {
+					sessionIDs = append(sessionIDs, cookie.Value)
+				}
+			}
+			return resp, err
+		})
+	}
+	recorder := cassette.NewRecorder()
+	c, err := client.New(srv.Host(), true, client.WithTransport(func(next http.RoundTripper) http.RoundTripper {
+		return recorder.Wrap(capture(next))
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.Login("admin", "top-secret-password", "test"))
+	require.NoError(t, c.Do(filestation.NewFileStationInfoRequest(2), &filestation.FileStationInfoResponse{}))
+	require.NotEmpty(t, sessionIDs, "session cookie must be set")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, recorder.Cassette().Save(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, sid := range sessionIDs {
+		assert.NotContains(t, string(content), sid)
+	}
+
+	loaded, err := cassette.Load(path)
+	require.NoError(t, err)
+	cookies := 0
+	for _, interaction := range loaded.Interactions {
+		if cookie, ok := interaction.Request.Header["Cookie"]; ok {
+			assert.Equal(t, []string{cassette.Redacted}, cookie, "session cookie must be redacted in request headers")
+			cookies++
+		}
+		if cookie, ok := interaction.Response.Header["Set-Cookie"]; ok {
+			assert.Equal(t, []string{cassette.Redacted}, cookie, "session cookie must be redacted in response headers")
+		}
+	}
+	assert.Positive(t, cookies)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,224 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+)
+
+var (
+	// secretFields are fields of JSON response body with redacted values.
+	secretFields = map[string]bool{
+		"sid":       true,
+		"synotoken": true,
+		"did":       true,
+	}
+
+	// recordedHeaders are response headers kept in cassette, secret ones are redacted.
+	recordedHeaders = []string{"Content-Type", "Content-Disposition", "Set-Cookie"}
+)
+
+// Recorder is an HTTP transport middleware recording all interactions.
+type Recorder struct {
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Wrap returns transport recording interactions performed via next.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		recorded, err := recordRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+			Request:  recorded,
+			Response: recordResponse(resp, body),
+		})
+
+		return resp, nil
+	})
+}
+
+// Cassette returns copy of recorded interactions.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{
+		Interactions: append([]Interaction(nil), r.cassette.Interactions...),
+	}
+}
+
+// recordRequest extracts matching information from request with secrets redacted.
+// Request body is restored, so it can be sent afterwards.
+func recordRequest(req *http.Request) (Request, error) {
+	params, err := requestParams(req)
+	if err != nil {
+		return Request{}, err
+	}
+	for k, values := range params {
+		if client.IsSecretParam(k) {
+			redact(values)
+		}
+	}
+	header := map[string][]string{}
+	for k, values := range req.Header {
+		header[k] = append([]string(nil), values...)
+		if client.IsSecretHeader(k) {
+			redact(header[k])
+		}
+	}
+
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Params: params,
+		Header: header,
+	}, nil
+}
+
+// redact replaces non-empty values with Redacted.
+func redact(values []string) {
+	for i, v := range values {
+		if v != "" {
+			values[i] = Redacted
+		}
+	}
+}
+
+// requestParams returns query and form parameters of request.
+// Files of multipart requests are not included.
+func requestParams(req *http.Request) (url.Values, error) {
+	params := url.Values{}
+	for k, v := range req.URL.Query() {
+		params[k] = append(params[k], v...)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return params, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	case "multipart/form-data":
+		parsed := &http.Request{Header: req.Header, Body: io.NopCloser(bytes.NewReader(body))}
+		if err := parsed.ParseMultipartForm(int64(len(body)) + 1); err != nil {
+			return nil, err
+		}
+		for k, v := range parsed.MultipartForm.Value {
+			params[k] = append(params[k], v...)
+		}
+	}
+
+	return params, nil
+}
+
+func recordResponse(resp *http.Response, body []byte) Response {
+	result := Response{
+		StatusCode: resp.StatusCode,
+		Header:     map[string][]string{},
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Values(h); len(v) > 0 {
+			result.Header[h] = append([]string(nil), v...)
+			if client.IsSecretHeader(h) {
+				redact(result.Header[h])
+			}
+		}
+	}
+
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") || json.Valid(body) {
+		body = redactJSON(body)
+	}
+	if utf8.Valid(body) {
+		result.Body = string(body)
+	} else {
+		result.BodyBase64 = body
+	}
+
+	return result
+}
+
+// redactJSON replaces values of secret fields in JSON document.
+func redactJSON(body []byte) []byte {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if secretFields[k] {
+				value[k] = Redacted
+				continue
+			}
+			value[k] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
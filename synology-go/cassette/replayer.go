@@ -0,0 +1,117 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+)
+
+// Replayer is an HTTP transport serving responses from cassette instead of remote instance.
+//
+// Requests are matched by method, path and parameters, parameters with redacted values are ignored.
+// Each interaction is replayed once, in the order of recording.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer creates replayer for cassette.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		used:     make([]bool, len(c.Interactions)),
+	}
+}
+
+// Wrap returns transport replaying interactions, next transport is never called.
+func (r *Replayer) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(r.RoundTrip)
+}
+
+// RoundTrip satisfies http.RoundTripper interface.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, err := requestParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, req, params) {
+			continue
+		}
+		r.used[i] = true
+
+		return replayResponse(req, interaction.Response), nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s?%s", req.Method, req.URL.Path, params.Encode())
+}
+
+// Unused returns number of interactions which were not replayed yet.
+func (r *Replayer) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, used := range r.used {
+		if !used {
+			count++
+		}
+	}
+
+	return count
+}
+
+func matches(recorded Request, req *http.Request, params url.Values) bool {
+	if recorded.Method != req.Method || recorded.Path != req.URL.Path {
+		return false
+	}
+
+	return reflect.DeepEqual(comparableParams(recorded.Params), comparableParams(params))
+}
+
+// comparableParams drops redacted and secret parameters.
+func comparableParams(params map[string][]string) map[string][]string {
+	result := map[string][]string{}
+	for k, v := range params {
+		if client.IsSecretParam(k) || (len(v) == 1 && v[0] == Redacted) {
+			continue
+		}
+		result[k] = v
+	}
+
+	return result
+}
+
+func replayResponse(req *http.Request, recorded Response) *http.Response {
+	body := []byte(recorded.Body)
+	if recorded.BodyBase64 != nil {
+		body = recorded.BodyBase64
+	}
+	header := http.Header{}
+	for k, v := range recorded.Header {
+		header[k] = append([]string(nil), v...)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
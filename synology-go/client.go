@@ -2,35 +2,206 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
-	"reflect"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
-	"github.com/mitchellh/mapstructure"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/auth"
 	"golang.org/x/net/publicsuffix"
 )
 
+const (
+	// DefaultLoginTimeout is the default time limit for a login request.
+	DefaultLoginTimeout = 10 * time.Second
+
+	// DefaultRequestTimeout is the default time limit for a single API request.
+	DefaultRequestTimeout = 3 * time.Second
+)
+
+// SessionTransport defines how session ID is passed to remote instance.
+type SessionTransport int
+
+const (
+	// SessionTransportCookie passes session ID in 'id' cookie.
+	SessionTransportCookie SessionTransport = iota
+
+	// SessionTransportQuery passes session ID in '_sid' query parameter.
+	SessionTransportQuery
+)
+
 type Client interface {
-	Login(user, password, sessionName string) error
+	// Login runs a login flow with default context.
+	Login(user, password, sessionName string, options ...LoginOption) error
+
+	// LoginContext runs a login flow bound to the provided context.
+	LoginContext(ctx context.Context, user, password, sessionName string, options ...LoginOption) error
+
+	// Logout terminates current session with default context.
+	Logout() error
+
+	// LogoutContext terminates current session bound to the provided context.
+	LogoutContext(ctx context.Context) error
+
+	// DeviceID returns trusted device token issued during login, if it was requested.
+	DeviceID() string
+
+	// Do performs a request with default context.
 	Do(r api.Request, response api.Response) error
+
+	// DoContext performs a request bound to the provided context.
+	DoContext(ctx context.Context, r api.Request, response api.Response) error
 }
 type client struct {
-	httpClient *http.Client
-	host       string
+	httpClient       *http.Client
+	baseURL          url.URL
+	loginTimeout     time.Duration
+	requestTimeout   time.Duration
+	sessionTransport SessionTransport
+	apiErrors        bool
+	retryPolicy      RetryPolicy
+	tls              tlsSettings
+	proxy            func(*http.Request) (*url.URL, error)
+	requestLogger    RequestLogger
+	rateLimiter      *rateLimiter
+	// inFlight holds a token for every request in flight when concurrency is limited.
+	inFlight chan struct{}
+
+	// transportWrappers are applied to HTTP transport once all options are processed.
+	transportWrappers []func(http.RoundTripper) http.RoundTripper
+
+	// sessionMu guards session state below.
+	sessionMu         sync.Mutex
+	credentials       *credentials
+	sessionGeneration uint64
+
+	// tokenMu guards session tokens attached to every request.
+	tokenMu   sync.RWMutex
+	sid       string
+	synoToken string
+
+	// apiInfoMu guards cached information about remote APIs.
+	apiInfoMu sync.Mutex
+	apis      map[string]APIInfo
+}
+
+// credentials holds login information to re-authenticate when session expires.
+type credentials struct {
+	user        string
+	password    string
+	sessionName string
+	otpCode     string
+	otpSecret   string
+	deviceName  string
+	deviceID    string
+}
+
+// LoginOption defines a function to customize login flow.
+type LoginOption func(*credentials)
+
+// WithOTPCode sets one-time code for accounts with 2-step verification.
+//
+// The code is used only once, so re-authentication after session expiry
+// requires either OTP secret or trusted device token.
+func WithOTPCode(code string) LoginOption {
+	return func(c *credentials) {
+		c.otpCode = code
+	}
+}
+
+// WithOTPSecret sets base32-encoded shared secret to generate one-time codes
+// for accounts with 2-step verification on every login.
+func WithOTPSecret(secret string) LoginOption {
+	return func(c *credentials) {
+		c.otpSecret = secret
+	}
+}
+
+// WithDeviceToken requests a trusted device token for device with the given name.
+// The token is available via Client.DeviceID after successful login.
+func WithDeviceToken(deviceName string) LoginOption {
+	return func(c *credentials) {
+		c.deviceName = deviceName
+	}
+}
+
+// WithDeviceID sets previously issued trusted device token to skip 2-step verification.
+func WithDeviceID(deviceID string) LoginOption {
+	return func(c *credentials) {
+		c.deviceID = deviceID
+	}
+}
+
+// Option defines a function to customize client during creation.
+type Option func(*client)
+
+// WithLoginTimeout sets time limit for login requests.
+// Zero value disables the limit, so only caller's context is respected.
+func WithLoginTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.loginTimeout = timeout
+	}
+}
+
+// WithRequestTimeout sets time limit for API requests.
+// Zero value disables the limit, so only caller's context is respected.
+// File uploads and downloads are not limited, since their duration depends on the size of files.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithSessionTransport sets the way session ID is passed to remote instance.
+func WithSessionTransport(transport SessionTransport) Option {
+	return func(c *client) {
+		c.sessionTransport = transport
+	}
+}
+
+// WithAPIErrors makes Do return API-level failures as api.SynologyError,
+// so callers can check a single error value, e.g. with errors.Is(err, api.ErrNotFound).
+// Response object still holds the same error.
+func WithAPIErrors() Option {
+	return func(c *client) {
+		c.apiErrors = true
+	}
+}
+
+// WithTransport wraps HTTP transport of the client, e.g. to record or replay interactions.
+// Wrappers are applied in the order of options, so the last one is the outermost.
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *client) {
+		c.transportWrappers = append(c.transportWrappers, wrap)
+	}
+}
+
+// WithProxy sets function returning proxy for a request, e.g. http.ProxyURL or http.ProxyFromEnvironment.
+// Requests are sent directly by default.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(c *client) {
+		c.proxy = proxy
+	}
 }
 
 // New initializes "client" instance with minimal input configuration.
-func New(host string, skipCertificateVerification bool) (Client, error) {
+//
+// host is either 'host:port' of remote instance reachable over HTTPS
+// or base URL, e.g. 'http://nas.local:5000' or 'https://gw.example.com/nas/', see ParseHost.
+func New(host string, skipCertificateVerification bool, options ...Option) (Client, error) {
+	baseURL, err := ParseHost(host)
+	if err != nil {
+		return nil, err
+	}
+
 	transport := &http.Transport{
 		DialContext: (&net.Dialer{
 			Timeout:   10 * time.Second,
@@ -41,12 +212,9 @@ func New(host string, skipCertificateVerification bool) (Client, error) {
 		IdleConnTimeout:       60 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: skipCertificateVerification,
-		},
 	}
 
-	// currently, 'Cookie' is the only supported method for providing 'sid' token to DSM
+	// cookies are used for session ID unless query transport is configured
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 	if err != nil {
 		return nil, err
@@ -56,82 +224,303 @@ func New(host string, skipCertificateVerification bool) (Client, error) {
 		Jar:       jar,
 	}
 
-	return &client{
-		httpClient: httpClient,
-		host:       host,
-	}, nil
+	c := &client{
+		httpClient:     httpClient,
+		baseURL:        *baseURL,
+		loginTimeout:   DefaultLoginTimeout,
+		requestTimeout: DefaultRequestTimeout,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	transport.TLSClientConfig = c.tls.config(skipCertificateVerification)
+	transport.Proxy = c.proxy
+	for _, wrap := range c.transportWrappers {
+		c.httpClient.Transport = wrap(c.httpClient.Transport)
+	}
+
+	return c, nil
 }
 
 // Login runs a login flow to retrieve session token from Synology.
-func (c *client) Login(user, password, sessionName string) error {
-	u := c.baseURL()
-
-	u.Path = "/webapi/entry.cgi"
-	q := u.Query()
-	q.Add("api", "SYNO.API.Auth")
-	q.Add("version", "7")
-	q.Add("method", "login")
-	q.Add("account", user)
-	q.Add("passwd", password)
-	q.Add("session", sessionName)
-	q.Add("format", "cookie")
-	u.RawQuery = q.Encode()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
+func (c *client) Login(user, password, sessionName string, options ...LoginOption) error {
+	return c.LoginContext(context.Background(), user, password, sessionName, options...)
+}
+
+// LoginContext runs a login flow to retrieve session token from Synology.
+//
+// The request is cancelled when either ctx is done or login timeout is reached.
+// Credentials are remembered on success to re-authenticate when session expires.
+func (c *client) LoginContext(ctx context.Context, user, password, sessionName string, options ...LoginOption) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	creds := &credentials{
+		user:        user,
+		password:    password,
+		sessionName: sessionName,
+	}
+	for _, option := range options {
+		option(creds)
+	}
+	if err := c.login(ctx, creds); err != nil {
 		return err
 	}
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	c.credentials = creds
+	c.sessionGeneration++
+
+	return nil
+}
+
+func (c *client) login(ctx context.Context, creds *credentials) error {
+	format := "cookie"
+	if c.sessionTransport == SessionTransportQuery {
+		format = "sid"
+	}
+	request := auth.NewLoginRequest(7).
+		WithAccount(creds.user).
+		WithPassword(creds.password).
+		WithSession(creds.sessionName).
+		WithFormat(format).
+		WithSynoToken(true).
+		WithDeviceID(creds.deviceID)
+	otpCode := creds.otpCode
+	if creds.otpSecret != "" {
+		code, err := GenerateTOTP(creds.otpSecret, time.Now())
+		if err != nil {
+			return err
+		}
+		otpCode = code
+	}
+	request.WithOTPCode(otpCode)
+	if creds.deviceName != "" {
+		request.WithDeviceToken(creds.deviceName)
+	}
+
+	response := auth.LoginResponse{}
+	if err := c.do(ctx, request, &response, c.loginTimeout); err != nil {
 		return err
 	}
-	defer func() {
-		_, _ = io.ReadAll(resp.Body)
-		_ = resp.Body.Close()
-	}()
+	if !response.Success() {
+		return LoginError{SynologyError: response.GetError()}
+	}
+
+	c.setSessionTokens(response.SID, response.SynoToken)
+
+	// one-time code can't be reused for re-authentication
+	creds.otpCode = ""
+	if response.DID != "" {
+		creds.deviceID = response.DID
+	}
+
+	return nil
+}
+
+// Logout terminates current session on Synology.
+func (c *client) Logout() error {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext terminates current session on Synology.
+//
+// Remembered credentials are discarded, so the client no longer re-authenticates automatically.
+// It is a no-op if the client is not logged in.
+func (c *client) LogoutContext(ctx context.Context) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.credentials == nil {
+		return nil
+	}
+
+	request := auth.NewLogoutRequest(7).WithSession(c.credentials.sessionName)
+	response := auth.LogoutResponse{}
+	if err := c.do(ctx, request, &response, c.requestTimeout); err != nil {
+		return err
+	}
+	c.credentials = nil
+	c.sessionGeneration++
+	c.setSessionTokens("", "")
+	if !response.Success() && !isSessionError(response.GetError().Code) {
+		return response.GetError()
+	}
 
 	return nil
 }
 
+// DeviceID returns trusted device token issued during login, if it was requested.
+func (c *client) DeviceID() string {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.credentials == nil {
+		return ""
+	}
+
+	return c.credentials.deviceID
+}
+
 // Do performs an HTTP request to remote Synology instance.
 //
 // Returns error in case of any transport errors.
 // For API-level errors, check response object.
-func (c client) Do(r api.Request, response api.Response) error {
-	u := c.baseURL()
+func (c *client) Do(r api.Request, response api.Response) error {
+	return c.DoContext(context.Background(), r, response)
+}
 
-	// request can override this path by implementing APIPathProvider interface
-	u.Path = "/webapi/entry.cgi"
+// DoContext performs an HTTP request to remote Synology instance.
+//
+// The request is cancelled when either ctx is done or request timeout is reached.
+// If the session has expired, the client logs in again with remembered credentials
+// and replays the request once, unless its body can't be replayed.
+// Transient failures are retried according to retry policy, see WithRetryPolicy.
+// Returns error in case of any transport errors.
+// For API-level errors, check response object or enable WithAPIErrors option.
+func (c *client) DoContext(ctx context.Context, r api.Request, response api.Response) error {
+	if err := c.doWithSession(ctx, r, response); err != nil {
+		return err
+	}
+	if c.apiErrors && !response.Success() {
+		return response.GetError()
+	}
+
+	return nil
+}
+
+// doWithSession performs a request, re-authenticating once if the session has expired.
+func (c *client) doWithSession(ctx context.Context, r api.Request, response api.Response) error {
+	generation := c.currentSessionGeneration()
+	if err := c.doWithRetry(ctx, r, response); err != nil {
+		return err
+	}
+	if !isSessionError(response.GetError().Code) {
+		return nil
+	}
+
+	refreshed, err := c.refreshSession(ctx, generation)
+	if err != nil {
+		return fmt.Errorf("session refresh failed: %w", err)
+	}
+	if !refreshed || !rewindRequest(r) {
+		return nil
+	}
+	response.SetError(api.SynologyError{})
+
+	return c.doWithRetry(ctx, r, response)
+}
+
+// doWithTimeout performs a single request attempt limited by request timeout.
+// File transfers take as long as the size of files requires, so they are bounded by ctx only.
+func (c *client) doWithTimeout(ctx context.Context, r api.Request, response api.Response) error {
+	timeout := c.requestTimeout
+	if isTransfer(r, response) {
+		timeout = 0
+	}
+
+	return c.do(ctx, r, response, timeout)
+}
+
+// do performs a single request attempt limited by timeout, zero timeout means no limit.
+func (c *client) do(ctx context.Context, r api.Request, response api.Response, timeout time.Duration) error {
 	query, err := marshalURL(r)
 	if err != nil {
 		return err
 	}
 
-	u.RawQuery = query.Encode()
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	// request can override this path by implementing APIPathProvider interface.
+	// API discovery is a request on its own, so it is resolved before the slot of this request is taken.
+	path, err := c.resolveAPI(ctx, r, query)
+	if err != nil {
+		return err
+	}
+
+	return c.sendThrottled(ctx, path, query, r, response, timeout)
+}
+
+// sendThrottled sends a request limited by timeout once it is allowed by rate and concurrency limits.
+// Time spent waiting is not counted towards the timeout.
+func (c *client) sendThrottled(ctx context.Context, path string, params url.Values, r api.Request, response api.Response, timeout time.Duration) error {
+	release, err := c.throttle(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := withTimeout(ctx, timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	return c.send(ctx, path, params, r, response)
+}
+
+// send performs an HTTP request to the path with params and decodes result into response.
+func (c *client) send(ctx context.Context, path string, params url.Values, r api.Request, response api.Response) error {
+	sid, synoToken := c.sessionTokens()
+	if sid != "" && c.sessionTransport == SessionTransportQuery {
+		params.Set("_sid", sid)
+	}
+
+	req, release, err := c.newHTTPRequest(ctx, path, params, r)
 	if err != nil {
 		return err
 	}
+	defer release()
+	if synoToken != "" {
+		req.Header.Set("X-SYNO-TOKEN", synoToken)
+	}
+
+	if c.requestLogger == nil {
+		return c.exchange(req, response, &RequestLog{})
+	}
 
+	entry := newRequestLog(req.Method, req.URL.Path, params, req.Header)
+	start := time.Now()
+	err = c.exchange(req, response, &entry)
+	entry.Duration = time.Since(start)
+	entry.Err = err
+	if err == nil {
+		entry.ErrorCode = response.GetError().Code
+	}
+	c.requestLogger(ctx, entry)
+
+	return err
+}
+
+// exchange sends HTTP request and decodes its result into response.
+// Content of responses implementing api.RawResponse is streamed into them as is.
+// HTTP status, size of response body and unknown response fields are reported to entry.
+func (c *client) exchange(req *http.Request, response api.Response, entry *RequestLog) error {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
+	entry.StatusCode = resp.StatusCode
+	body := &countingReader{Reader: resp.Body}
 	defer func() {
-		_, _ = io.ReadAll(resp.Body)
+		_, _ = io.ReadAll(body)
 		_ = resp.Body.Close()
+		entry.ResponseSize = body.n
 	}()
+	if resp.StatusCode != http.StatusOK {
+		return HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+	if raw, ok := response.(api.RawResponse); ok && !isJSONEnvelope(resp.Header) {
+		response.SetError(api.SynologyError{})
+		if err := raw.ReadBody(resp.Header, body); err != nil {
+			return streamError{err: err}
+		}
+
+		return nil
+	}
 
 	synoResponse := api.GenericResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&synoResponse); err != nil {
+	decoder := json.NewDecoder(body)
+	// keep numbers as is, so large integers don't lose precision
+	decoder.UseNumber()
+	if err := decoder.Decode(&synoResponse); err != nil {
 		return err
 	}
-	if err := mapstructure.Decode(synoResponse.Data, response); err != nil {
+	entry.UnknownFields, err = decodeResponse(synoResponse.Data, response)
+	if err != nil {
 		return err
 	}
 	response.SetError(handleErrors(synoResponse, response, api.GlobalErrors))
@@ -139,11 +528,129 @@ func (c client) Do(r api.Request, response api.Response) error {
 	return nil
 }
 
-func (c client) baseURL() url.URL {
-	return url.URL{
-		Scheme: "https",
-		Host:   c.host,
+func (c *client) setSessionTokens(sid, synoToken string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	c.sid = sid
+	c.synoToken = synoToken
+}
+
+func (c *client) sessionTokens() (string, string) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+
+	return c.sid, c.synoToken
+}
+
+func (c *client) currentSessionGeneration() uint64 {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	return c.sessionGeneration
+}
+
+// refreshSession logs in again with remembered credentials.
+//
+// generation is the session generation observed before the failed request.
+// If the session has been refreshed by another caller since then, no login is performed,
+// so concurrent requests failed with the same expired session trigger only one login.
+// Reports false if there are no credentials to log in with.
+func (c *client) refreshSession(ctx context.Context, generation uint64) (bool, error) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.credentials == nil {
+		return false, nil
+	}
+	if c.sessionGeneration != generation {
+		return true, nil
+	}
+	if err := c.login(ctx, c.credentials); err != nil {
+		return false, err
+	}
+	c.sessionGeneration++
+
+	return true, nil
+}
+
+// ParseHost returns base URL of remote instance.
+//
+// host is either 'host:port', in which case HTTPS is used,
+// or URL with http or https scheme and optional path prefix,
+// e.g. when remote instance is served by reverse proxy.
+func ParseHost(host string) (*url.URL, error) {
+	if host == "" {
+		return nil, errors.New("host must not be empty")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid host %q: scheme must be either http or https", host)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid host %q: host name is missing", host)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid host %q: credentials, query and fragment are not allowed", host)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	return u, nil
+}
+
+// withTimeout derives a context with timeout from parent.
+// Non-positive timeout leaves parent's deadline as is.
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
 	}
+
+	return context.WithTimeout(parent, timeout)
+}
+
+// LoginError is returned when remote instance rejects login request.
+// Code holds one of auth.Error* values for authentication-specific failures.
+type LoginError struct {
+	api.SynologyError
+}
+
+// Error satisfies error interface for LoginError type.
+func (e LoginError) Error() string {
+	return "login failed: " + e.SynologyError.Error()
+}
+
+// Unwrap returns underlying API error.
+func (e LoginError) Unwrap() error {
+	return e.SynologyError
+}
+
+// HTTPStatusError is returned when remote instance responds with unexpected HTTP status,
+// e.g. when web server is up, but DSM services are restarting.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+// Error satisfies error interface for HTTPStatusError type.
+func (e HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// isSessionError reports whether the code means that current session is no longer valid.
+func isSessionError(code int) bool {
+	switch code {
+	case 106, 107, 119:
+		return true
+	}
+
+	return false
 }
 
 func handleErrors(response api.GenericResponse, errorDescriber api.ErrorDescriber, knownErrors api.ErrorSummary) api.SynologyError {
@@ -156,6 +663,12 @@ func handleErrors(response api.GenericResponse, errorDescriber api.ErrorDescribe
 
 	combinedKnownErrors := append(errorDescriber.ErrorSummaries(), knownErrors)
 	err.Summary = api.DescribeError(err.Code, combinedKnownErrors...)
+	sentinels := []api.SentinelErrors{}
+	if p, ok := errorDescriber.(api.SentinelErrorProvider); ok {
+		sentinels = append(sentinels, p.SentinelErrors())
+	}
+	sentinels = append(sentinels, api.GlobalSentinelErrors)
+	err.Sentinel = findSentinel(err.Code, sentinels)
 	for _, e := range response.Error.Errors {
 		item := api.ErrorItem{
 			Code:    e.Code,
@@ -168,77 +681,23 @@ func handleErrors(response api.GenericResponse, errorDescriber api.ErrorDescribe
 			}
 		}
 		err.Errors = append(err.Errors, item)
+		// detailed errors are more specific when top-level code is generic, e.g. 1100 with 414 inside
+		if err.Sentinel == nil {
+			err.Sentinel = findSentinel(e.Code, sentinels)
+		}
 	}
 
 	return err
 }
 
-func marshalURL(r interface{}) (url.Values, error) {
-	v := reflect.Indirect(reflect.ValueOf(r))
-	if v.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("expected type struct, got %T", reflect.TypeOf(r).Name())
-	}
-	n := v.NumField()
-	vT := v.Type()
-	ret := url.Values{}
-	for i := 0; i < n; i++ {
-		urlFieldName := strings.ToLower(vT.Field(i).Name)
-		synologyTags := []string{}
-		if tags, ok := vT.Field(i).Tag.Lookup("synology"); ok {
-			synologyTags = strings.Split(tags, ",")
-		}
-		if !(vT.Field(i).IsExported() || vT.Field(i).Anonymous || len(synologyTags) > 0) {
-			continue
-		}
-		if len(synologyTags) > 0 {
-			urlFieldName = synologyTags[0]
-		}
-
-		// get field type
-		switch vT.Field(i).Type.Kind() {
-		case reflect.String:
-			ret.Add(urlFieldName, v.Field(i).String())
-		case reflect.Int:
-			ret.Add(urlFieldName, strconv.Itoa(int(v.Field(i).Int())))
-		case reflect.Bool:
-			ret.Add(urlFieldName, strconv.FormatBool(v.Field(i).Bool()))
-		case reflect.Slice:
-			slice := v.Field(i)
-			switch vT.Field(i).Type.Elem().Kind() {
-			case reflect.String:
-				res := []string{}
-				for iSlice := 0; iSlice < slice.Len(); iSlice++ {
-					item := slice.Index(iSlice)
-					res = append(res, item.String())
-				}
-				ret.Add(urlFieldName, "[\""+strings.Join(res, "\",\"")+"\"]")
-			case reflect.Int:
-				res := []string{}
-				for iSlice := 0; iSlice < slice.Len(); iSlice++ {
-					item := slice.Index(iSlice)
-					res = append(res, strconv.Itoa(int(item.Int())))
-				}
-				ret.Add(urlFieldName, "["+strings.Join(res, ",")+"]")
-			}
-		case reflect.Struct:
-			if !vT.Field(i).Anonymous {
-				// support only embedded anonymous structs
-				continue
-			}
-			embStruct := v.Field(i)
-			embStructT := v.Field(i).Type()
-			for j := 0; j < embStruct.NumField(); j++ {
-				synologyTags := strings.Split(embStructT.Field(j).Tag.Get("synology"), ",")
-				fieldName := synologyTags[0]
-				switch embStruct.Field(j).Kind() {
-				case reflect.String:
-					ret.Add(fieldName, embStruct.Field(j).String())
-				case reflect.Int:
-					ret.Add(fieldName, strconv.Itoa(int(embStruct.Field(j).Int())))
-				}
-			}
+// findSentinel returns sentinel error for the code.
+// First mapping with this code wins.
+func findSentinel(code int, sentinels []api.SentinelErrors) error {
+	for _, m := range sentinels {
+		if sentinel, ok := m[code]; ok {
+			return sentinel
 		}
 	}
 
-	return ret, nil
+	return nil
 }
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/mitchellh/mapstructure"
+)
+
+// decodeResponse decodes data of API response into response object.
+//
+// Fields are matched by name from `synology` tag, falling back to case-insensitive field name.
+// DSM quirks are handled on the way: numbers and booleans sent as strings,
+// "yes"/"no" booleans and timestamps as seconds since epoch.
+// A field tagged with `synology:",remain"` of map[string]interface{} type collects unknown fields.
+//
+// Returns names of fields present in data, but unknown to response.
+func decodeResponse(data interface{}, response api.Response) ([]string, error) {
+	metadata := mapstructure.Metadata{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			boolDecodeHook,
+			timeDecodeHook,
+		),
+		WeaklyTypedInput: true,
+		Metadata:         &metadata,
+		TagName:          "synology",
+		Result:           response,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(data); err != nil {
+		return nil, fmt.Errorf("response decoding failed: %w", err)
+	}
+
+	return metadata.Unused, nil
+}
+
+// boolDecodeHook converts "yes"/"no" and "on"/"off" strings to booleans.
+// Other strings are handled by weak typing, e.g. "true" or "1".
+func boolDecodeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+
+	switch strings.ToLower(reflect.ValueOf(data).String()) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+
+	return data, nil
+}
+
+// timeDecodeHook converts seconds since epoch and RFC 3339 strings to time.Time.
+func timeDecodeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(time.Time{}) {
+		return data, nil
+	}
+
+	switch v := data.(type) {
+	case json.Number:
+		return epochTime(string(v))
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case int:
+		return time.Unix(int64(v), 0), nil
+	case int64:
+		return time.Unix(v, 0), nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		return epochTime(v)
+	}
+
+	return data, nil
+}
+
+// epochTime parses seconds since epoch, possibly with fractional part.
+func epochTime(s string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
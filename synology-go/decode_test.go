@@ -0,0 +1,141 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodedResponse struct {
+	testResponse
+
+	Name     string                 `synology:"real_name"`
+	Size     int64                  `synology:"size"`
+	Ratio    float64                `synology:"ratio"`
+	Enabled  bool                   `synology:"enabled"`
+	Modified time.Time              `synology:"mtime"`
+	Owner    *string                `synology:"owner"`
+	IsDir    bool                   `synology:"isdir"`
+	Extra    map[string]interface{} `synology:",remain"`
+}
+
+var _ api.Response = (*decodedResponse)(nil)
+
+func TestDecodeResponse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expected    decodedResponse
+		expectedErr string
+	}{
+		{
+			name: "native types",
+			data: `{"real_name":"folder","size":9007199254740993,"ratio":0.5,"enabled":true,"mtime":1700000000,"isdir":true}`,
+			expected: decodedResponse{
+				Name:     "folder",
+				Size:     9007199254740993,
+				Ratio:    0.5,
+				Enabled:  true,
+				Modified: time.Unix(1700000000, 0),
+				IsDir:    true,
+			},
+		},
+		{
+			name: "values as strings",
+			data: `{"size":"1024","ratio":"0.25","enabled":"true","mtime":"1700000000"}`,
+			expected: decodedResponse{
+				Size:     1024,
+				Ratio:    0.25,
+				Enabled:  true,
+				Modified: time.Unix(1700000000, 0),
+			},
+		},
+		{
+			name: "yes/no booleans",
+			data: `{"enabled":"yes","isdir":"NO"}`,
+			expected: decodedResponse{
+				Enabled: true,
+			},
+		},
+		{
+			name: "numeric booleans",
+			data: `{"enabled":1}`,
+			expected: decodedResponse{
+				Enabled: true,
+			},
+		},
+		{
+			name: "RFC 3339 time",
+			data: `{"mtime":"2023-11-14T22:13:20Z"}`,
+			expected: decodedResponse{
+				Modified: time.Unix(1700000000, 0),
+			},
+		},
+		{
+			name: "optional field",
+			data: `{"owner":"admin"}`,
+			expected: decodedResponse{
+				Owner: pointer("admin"),
+			},
+		},
+		{
+			name: "unknown fields",
+			data: `{"real_name":"folder","uid":1026,"perm":{"acl":true}}`,
+			expected: decodedResponse{
+				Name: "folder",
+				Extra: map[string]interface{}{
+					"uid":  json.Number("1026"),
+					"perm": map[string]interface{}{"acl": true},
+				},
+			},
+		},
+		{
+			name:        "invalid value",
+			data:        `{"size":"large"}`,
+			expectedErr: "response decoding failed",
+		},
+		{
+			name:        "invalid time",
+			data:        `{"mtime":"yesterday"}`,
+			expectedErr: `invalid time "yesterday"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(tc.data))
+			decoder.UseNumber()
+			data := map[string]interface{}{}
+			require.NoError(t, decoder.Decode(&data))
+
+			response := decodedResponse{}
+			_, err := decodeResponse(data, &response)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Modified.Equal(response.Modified))
+			tc.expected.Modified, response.Modified = time.Time{}, time.Time{}
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func TestDecodeResponse_unknownFields(t *testing.T) {
+	response := struct {
+		testResponse
+
+		Name string `synology:"name"`
+	}{}
+
+	unknown, err := decodeResponse(map[string]interface{}{"name": "folder", "uid": 1026}, &response)
+	require.NoError(t, err)
+	assert.Equal(t, "folder", response.Name)
+	assert.Equal(t, []string{"uid"}, unknown)
+}
@@ -0,0 +1,77 @@
+package dsmtest
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handleAPIInfoQuery(s *Server, r *request) (interface{}, error) {
+	query := r.FormValue("query")
+	result := map[string]APIInfo{}
+	for name, info := range s.APIs() {
+		if query == "" || query == "ALL" || containsString(strings.Split(query, ","), name) {
+			result[name] = info
+		}
+	}
+
+	return result, nil
+}
+
+func handleAuthLogin(s *Server, r *request) (interface{}, error) {
+	account := r.FormValue("account")
+	u, ok := s.users[account]
+	if !ok || u.password != r.FormValue("passwd") {
+		return nil, newError(400)
+	}
+
+	deviceID := ""
+	if u.otpCode != "" && !u.devices[r.FormValue("device_id")] {
+		switch otpCode := r.FormValue("otp_code"); {
+		case otpCode == "":
+			return nil, newError(403)
+		case otpCode != u.otpCode:
+			return nil, newError(404)
+		}
+		if boolParam(r, "enable_device_token") {
+			deviceID = randomToken()
+			u.devices[deviceID] = true
+		}
+	}
+
+	sid := randomToken()
+	sess := &session{account: account, name: r.FormValue("session")}
+	if boolParam(r, "enable_syno_token") {
+		sess.synoToken = randomToken()
+	}
+	s.sessions[sid] = sess
+
+	if r.FormValue("format") != "sid" {
+		http.SetCookie(r.w, &http.Cookie{Name: "id", Value: sid, Path: "/"})
+	}
+
+	data := map[string]interface{}{"sid": sid}
+	if deviceID != "" {
+		data["did"] = deviceID
+	}
+	if sess.synoToken != "" {
+		data["synotoken"] = sess.synoToken
+	}
+
+	return data, nil
+}
+
+func handleAuthLogout(s *Server, r *request) (interface{}, error) {
+	delete(s.sessions, r.sid)
+
+	return nil, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
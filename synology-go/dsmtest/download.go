@@ -0,0 +1,79 @@
+package dsmtest
+
+import (
+	"archive/zip"
+	"bytes"
+	"path"
+)
+
+// rawContent is returned by handlers sending content instead of JSON envelope, e.g. downloads.
+type rawContent struct {
+	contentType string
+	fileName    string
+	body        []byte
+}
+
+func handleFileStationDownload(s *Server, r *request) (interface{}, error) {
+	paths := listParam(r, "path")
+	if len(paths) == 0 {
+		return nil, newError(401)
+	}
+
+	nodes := make([]*node, 0, len(paths))
+	for _, p := range paths {
+		n, err := s.fs.lookup(p)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 && !nodes[0].isDir {
+		return &rawContent{
+			contentType: "application/octet-stream",
+			fileName:    nodes[0].name,
+			body:        nodes[0].content,
+		}, nil
+	}
+
+	// like DSM, folders and multiple files are sent as a zip archive
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, n := range nodes {
+		if err := writeZip(zw, n.name, n); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	fileName := "download.zip"
+	if len(nodes) == 1 {
+		fileName = nodes[0].name + ".zip"
+	}
+
+	return &rawContent{contentType: "application/zip", fileName: fileName, body: buf.Bytes()}, nil
+}
+
+// writeZip adds node with all its descendants to the archive under name.
+func writeZip(zw *zip.Writer, name string, n *node) error {
+	if n.isDir {
+		if _, err := zw.Create(name + "/"); err != nil {
+			return err
+		}
+		for _, child := range n.sortedChildren() {
+			if err := writeZip(zw, path.Join(name, child.name), child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(n.content)
+
+	return err
+}
@@ -0,0 +1,300 @@
+package dsmtest
+
+import (
+	"io"
+	"path"
+	"time"
+)
+
+// AddShare creates shared folder, i.e. top-level directory of virtual file system.
+func (s *Server) AddShare(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fs.root.children[name]; !ok {
+		s.fs.root.children[name] = newDir(name, "admin")
+	}
+}
+
+// MkdirAll creates directory with all missing parents.
+// The share, i.e. the first path component, must exist.
+func (s *Server) MkdirAll(p string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n, err := s.fs.lookup(p); err == nil && n.isDir {
+		return nil
+	}
+	_, err := s.fs.mkdir(p, true, "admin")
+
+	return err
+}
+
+// WriteFile creates or replaces file with content, creating missing parent directories.
+func (s *Server) WriteFile(p string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.fs.writeFile(p, content, true, true, "admin")
+
+	return err
+}
+
+// ReadFile returns content of the file.
+func (s *Server) ReadFile(p string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.fs.lookup(p)
+	if err != nil {
+		return nil, err
+	}
+	if n.isDir {
+		return nil, newError(400)
+	}
+
+	return append([]byte(nil), n.content...), nil
+}
+
+// Exists reports whether file or directory exists.
+func (s *Server) Exists(p string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.fs.lookup(p)
+
+	return err == nil
+}
+
+// IsDir reports whether path exists and is a directory.
+func (s *Server) IsDir(p string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.fs.lookup(p)
+
+	return err == nil && n.isDir
+}
+
+// RemoveAll deletes file or directory with all its content.
+func (s *Server) RemoveAll(p string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fs.remove(p, true)
+}
+
+func handleFileStationInfoGet(s *Server, r *request) (interface{}, error) {
+	return map[string]interface{}{
+		"hostname":                 s.hostname,
+		"is_manager":               r.session.account == "admin",
+		"support_sharing":          true,
+		"support_virtual_protocol": "cifs,nfs,iso",
+		"uid":                      1026,
+	}, nil
+}
+
+func handleFileStationCreateFolder(s *Server, r *request) (interface{}, error) {
+	folderPaths := listParam(r, "folder_path")
+	names := listParam(r, "name")
+	if len(folderPaths) == 0 || len(names) == 0 {
+		return nil, newError(401)
+	}
+	if len(names) != len(folderPaths) && len(names) != 1 && len(folderPaths) != 1 {
+		return nil, newError(401)
+	}
+
+	count := len(folderPaths)
+	if len(names) > count {
+		count = len(names)
+	}
+	folders := []map[string]interface{}{}
+	for i := 0; i < count; i++ {
+		folderPath := folderPaths[0]
+		if len(folderPaths) > 1 {
+			folderPath = folderPaths[i]
+		}
+		name := names[0]
+		if len(names) > 1 {
+			name = names[i]
+		}
+
+		p := path.Join(folderPath, name)
+		if _, err := s.fs.mkdir(p, boolParam(r, "force_parent"), r.session.account); err != nil {
+			return nil, fileOperationError(1100, p, err)
+		}
+		folders = append(folders, map[string]interface{}{
+			"isdir": true,
+			"name":  name,
+			"path":  p,
+		})
+	}
+
+	return map[string]interface{}{"folders": folders}, nil
+}
+
+func handleFileStationRename(s *Server, r *request) (interface{}, error) {
+	paths := listParam(r, "path")
+	names := listParam(r, "name")
+	if len(paths) == 0 || len(paths) != len(names) {
+		return nil, newError(401)
+	}
+
+	files := []map[string]interface{}{}
+	for i, p := range paths {
+		n, newPath, err := s.fs.rename(p, names[i])
+		if err != nil {
+			return nil, fileOperationError(1200, p, err)
+		}
+		files = append(files, map[string]interface{}{
+			"isdir": n.isDir,
+			"name":  n.name,
+			"path":  newPath,
+		})
+	}
+
+	return map[string]interface{}{"files": files}, nil
+}
+
+func handleFileStationGetInfo(s *Server, r *request) (interface{}, error) {
+	paths := listParam(r, "path")
+	if len(paths) == 0 {
+		return nil, newError(401)
+	}
+
+	additional := listParam(r, "additional")
+	files := []map[string]interface{}{}
+	for _, p := range paths {
+		n, err := s.fs.lookup(p)
+		if err != nil {
+			// missing paths are reported per file, the request itself succeeds
+			code := 408
+			if fsErr, ok := err.(*Error); ok {
+				code = fsErr.Code
+			}
+			files = append(files, map[string]interface{}{
+				"code": code,
+				"name": path.Base(p),
+				"path": p,
+			})
+			continue
+		}
+		files = append(files, fileEntry(path.Clean(p), n, additional))
+	}
+
+	return map[string]interface{}{"files": files}, nil
+}
+
+func handleFileStationUpload(s *Server, r *request) (interface{}, error) {
+	// like DSM, refuse streamed uploads of unknown size
+	if r.ContentLength < 0 {
+		return nil, newError(1800)
+	}
+	folderPath := r.FormValue("path")
+	file, header, err := r.FormFile("file")
+	if folderPath == "" || err != nil {
+		return nil, newError(401)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, newError(1803)
+	}
+
+	p := path.Join(folderPath, header.Filename)
+	overwrite := r.FormValue("overwrite")
+	if existing, err := s.fs.lookup(p); err == nil {
+		switch {
+		case existing.isDir:
+			return nil, newError(414)
+		case overwrite == "":
+			return nil, newError(1805)
+		case !boolParam(r, "overwrite"):
+			return map[string]interface{}{"blSkip": true, "file": header.Filename}, nil
+		}
+	}
+
+	n, err := s.fs.writeFile(p, content, boolParam(r, "create_parents"), true, r.session.account)
+	if err != nil {
+		return nil, err
+	}
+	if mtime := intParam(r, "mtime"); mtime > 0 {
+		n.modTime = time.UnixMilli(int64(mtime))
+	}
+	if crtime := intParam(r, "crtime"); crtime > 0 {
+		n.crTime = time.UnixMilli(int64(crtime))
+	}
+
+	return map[string]interface{}{"blSkip": false, "file": header.Filename}, nil
+}
+
+// task is an asynchronous file operation.
+// Operations are performed at once on start, so tasks are always finished.
+type task struct {
+	paths []string
+	err   error
+}
+
+func handleFileStationDeleteStart(s *Server, r *request) (interface{}, error) {
+	paths := listParam(r, "path")
+	if len(paths) == 0 {
+		return nil, newError(401)
+	}
+	recursive := r.FormValue("recursive") == "" || boolParam(r, "recursive")
+
+	t := &task{paths: paths}
+	for _, p := range paths {
+		if err := s.fs.remove(p, recursive); err != nil {
+			t.err = fileOperationError(900, p, err)
+			break
+		}
+	}
+	taskID := "FileStation_" + randomToken()
+	s.tasks[taskID] = t
+
+	return map[string]interface{}{"taskid": taskID}, nil
+}
+
+func handleFileStationDeleteStatus(s *Server, r *request) (interface{}, error) {
+	t, ok := s.tasks[r.FormValue("taskid")]
+	if !ok {
+		return nil, newError(599)
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return map[string]interface{}{
+		"finished":        true,
+		"path":            t.paths[len(t.paths)-1],
+		"processed_num":   len(t.paths),
+		"processing_path": "",
+		"progress":        1,
+		"total":           len(t.paths),
+	}, nil
+}
+
+func handleFileStationDeleteStop(s *Server, r *request) (interface{}, error) {
+	if _, ok := s.tasks[r.FormValue("taskid")]; !ok {
+		return nil, newError(599)
+	}
+	delete(s.tasks, r.FormValue("taskid"))
+
+	return nil, nil
+}
+
+// fileOperationError wraps file system error into API-specific error with details,
+// the way FileStation reports failures of batch operations.
+func fileOperationError(code int, p string, err error) error {
+	fsErr, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+
+	return &Error{
+		Code: code,
+		Errors: []map[string]interface{}{
+			{"code": fsErr.Code, "path": p},
+		},
+	}
+}
@@ -0,0 +1,238 @@
+package dsmtest
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// node is a file or directory of virtual file system.
+type node struct {
+	name     string
+	isDir    bool
+	content  []byte
+	owner    string
+	modTime  time.Time
+	crTime   time.Time
+	children map[string]*node
+}
+
+// fileSystem is an in-memory tree with shares as top-level directories.
+type fileSystem struct {
+	root *node
+}
+
+func newFileSystem() *fileSystem {
+	return &fileSystem{root: newDir("", "root")}
+}
+
+func newDir(name, owner string) *node {
+	now := time.Now()
+	return &node{
+		name:     name,
+		isDir:    true,
+		owner:    owner,
+		modTime:  now,
+		crTime:   now,
+		children: map[string]*node{},
+	}
+}
+
+// splitPath validates absolute path and returns its components.
+func splitPath(p string) ([]string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return nil, newError(418)
+	}
+	p = path.Clean(p)
+	if p == "/" {
+		return nil, nil
+	}
+
+	return strings.Split(strings.TrimPrefix(p, "/"), "/"), nil
+}
+
+// lookup returns node at path.
+func (fs *fileSystem) lookup(p string) (*node, error) {
+	parts, err := splitPath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	current := fs.root
+	for _, part := range parts {
+		if !current.isDir {
+			return nil, newError(408)
+		}
+		next, ok := current.children[part]
+		if !ok {
+			return nil, newError(408)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// lookupParent returns parent directory of path, optionally creating missing directories.
+// Shares, i.e. top-level directories, are never created implicitly.
+func (fs *fileSystem) lookupParent(p string, createParents bool, owner string) (*node, string, error) {
+	parts, err := splitPath(p)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(parts) < 2 {
+		// shares can't be managed via FileStation
+		return nil, "", newError(407)
+	}
+
+	current := fs.root
+	for i, part := range parts[:len(parts)-1] {
+		next, ok := current.children[part]
+		if !ok {
+			if !createParents || i == 0 {
+				return nil, "", newError(408)
+			}
+			next = newDir(part, owner)
+			current.children[part] = next
+		}
+		if !next.isDir {
+			return nil, "", newError(408)
+		}
+		current = next
+	}
+
+	return current, parts[len(parts)-1], nil
+}
+
+// mkdir creates directory at path.
+func (fs *fileSystem) mkdir(p string, createParents bool, owner string) (*node, error) {
+	parent, name, err := fs.lookupParent(p, createParents, owner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := parent.children[name]; ok {
+		return nil, newError(414)
+	}
+	dir := newDir(name, owner)
+	parent.children[name] = dir
+
+	return dir, nil
+}
+
+// writeFile creates or replaces file at path.
+func (fs *fileSystem) writeFile(p string, content []byte, createParents, overwrite bool, owner string) (*node, error) {
+	parent, name, err := fs.lookupParent(p, createParents, owner)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	existing, ok := parent.children[name]
+	if ok && (existing.isDir || !overwrite) {
+		return nil, newError(414)
+	}
+
+	file := &node{
+		name:    name,
+		content: append([]byte(nil), content...),
+		owner:   owner,
+		modTime: now,
+		crTime:  now,
+	}
+	if ok {
+		file.crTime = existing.crTime
+	}
+	parent.children[name] = file
+
+	return file, nil
+}
+
+// rename changes name of the node at path.
+func (fs *fileSystem) rename(p, newName string) (*node, string, error) {
+	if newName == "" || strings.Contains(newName, "/") {
+		return nil, "", newError(419)
+	}
+	parent, name, err := fs.lookupParent(p, false, "")
+	if err != nil {
+		return nil, "", err
+	}
+	n, ok := parent.children[name]
+	if !ok {
+		return nil, "", newError(408)
+	}
+	if newName == name {
+		return n, p, nil
+	}
+	if _, ok := parent.children[newName]; ok {
+		return nil, "", newError(414)
+	}
+	delete(parent.children, name)
+	n.name = newName
+	n.modTime = time.Now()
+	parent.children[newName] = n
+
+	return n, path.Join(path.Dir(path.Clean(p)), newName), nil
+}
+
+// remove deletes node at path.
+func (fs *fileSystem) remove(p string, recursive bool) error {
+	parent, name, err := fs.lookupParent(p, false, "")
+	if err != nil {
+		return err
+	}
+	n, ok := parent.children[name]
+	if !ok {
+		return newError(408)
+	}
+	if n.isDir && len(n.children) > 0 && !recursive {
+		return newError(400)
+	}
+	delete(parent.children, name)
+
+	return nil
+}
+
+// sortedChildren returns children of directory sorted by name.
+func (n *node) sortedChildren() []*node {
+	result := make([]*node, 0, len(n.children))
+	for _, child := range n.children {
+		result = append(result, child)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result
+}
+
+// size returns size of file content.
+func (n *node) size() int {
+	return len(n.content)
+}
+
+// uid returns ID of the owner.
+func (n *node) uid() int {
+	if n.owner == "root" {
+		return 0
+	}
+
+	return 1024
+}
+
+// posix returns POSIX permission in octal digits.
+func (n *node) posix() int {
+	if n.isDir {
+		return 755
+	}
+
+	return 644
+}
+
+// fileType returns upper-cased extension of file, it is empty for directories.
+func (n *node) fileType() string {
+	if n.isDir {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimPrefix(path.Ext(n.name), "."))
+}
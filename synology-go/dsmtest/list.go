@@ -0,0 +1,188 @@
+package dsmtest
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// realPathPrefix is the volume shares are located on.
+const realPathPrefix = "/volume1"
+
+func handleFileStationListShare(s *Server, r *request) (interface{}, error) {
+	additional := listParam(r, "additional")
+	entries := []map[string]interface{}{}
+	for _, n := range sortNodes(s.fs.root.sortedChildren(), r.FormValue("sort_by"), r.FormValue("sort_direction")) {
+		entry := fileEntry("/"+n.name, n, additional)
+		if extra, ok := entry["additional"].(map[string]interface{}); ok {
+			if perm, ok := extra["perm"].(map[string]interface{}); ok {
+				perm["share_right"] = "RW"
+			}
+			if containsString(additional, "volume_status") {
+				extra["volume_status"] = map[string]interface{}{
+					"freespace":  int64(1 << 40),
+					"readonly":   false,
+					"totalspace": int64(4 << 40),
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	offset, total, page := paginate(r, entries)
+
+	return map[string]interface{}{"offset": offset, "shares": page, "total": total}, nil
+}
+
+func handleFileStationList(s *Server, r *request) (interface{}, error) {
+	folderPath := r.FormValue("folder_path")
+	if folderPath == "" {
+		return nil, newError(401)
+	}
+	dir, err := s.fs.lookup(folderPath)
+	if err != nil {
+		return nil, err
+	}
+	if !dir.isDir {
+		return nil, newError(408)
+	}
+
+	patterns := []string{}
+	if v := r.FormValue("pattern"); v != "" {
+		patterns = strings.Split(v, ",")
+	}
+	fileType := r.FormValue("filetype")
+	additional := listParam(r, "additional")
+	entries := []map[string]interface{}{}
+	for _, n := range sortNodes(dir.sortedChildren(), r.FormValue("sort_by"), r.FormValue("sort_direction")) {
+		if (fileType == "file" && n.isDir) || (fileType == "dir" && !n.isDir) || !matchPatterns(patterns, n.name) {
+			continue
+		}
+		entries = append(entries, fileEntry(path.Join(path.Clean(folderPath), n.name), n, additional))
+	}
+
+	offset, total, page := paginate(r, entries)
+
+	return map[string]interface{}{"files": page, "offset": offset, "total": total}, nil
+}
+
+// fileEntry describes node at path the way FileStation does, with requested additional attributes.
+func fileEntry(p string, n *node, additional []string) map[string]interface{} {
+	entry := map[string]interface{}{
+		"isdir": n.isDir,
+		"name":  n.name,
+		"path":  p,
+	}
+	if len(additional) == 0 {
+		return entry
+	}
+
+	extra := map[string]interface{}{}
+	for _, name := range additional {
+		switch name {
+		case "real_path":
+			extra[name] = realPathPrefix + p
+		case "size":
+			extra[name] = n.size()
+		case "owner":
+			extra[name] = map[string]interface{}{
+				"gid":   100,
+				"group": "users",
+				"uid":   n.uid(),
+				"user":  n.owner,
+			}
+		case "time":
+			extra[name] = map[string]interface{}{
+				"atime":  n.modTime.Unix(),
+				"crtime": n.crTime.Unix(),
+				"ctime":  n.modTime.Unix(),
+				"mtime":  n.modTime.Unix(),
+			}
+		case "perm":
+			extra[name] = map[string]interface{}{
+				"acl": map[string]interface{}{
+					"append": true,
+					"del":    true,
+					"exec":   true,
+					"read":   true,
+					"write":  true,
+				},
+				"is_acl_mode": false,
+				"posix":       n.posix(),
+			}
+		case "mount_point_type":
+			extra[name] = ""
+		case "type":
+			extra[name] = n.fileType()
+		}
+	}
+	entry["additional"] = extra
+
+	return entry
+}
+
+// sortNodes sorts nodes by attribute, name ascending order is used by default.
+func sortNodes(nodes []*node, sortBy, direction string) []*node {
+	less := func(a, b *node) bool {
+		switch sortBy {
+		case "size":
+			return a.size() < b.size()
+		case "user":
+			return a.owner < b.owner
+		case "mtime", "atime", "ctime":
+			return a.modTime.Before(b.modTime)
+		case "crtime":
+			return a.crTime.Before(b.crTime)
+		case "posix":
+			return a.posix() < b.posix()
+		case "type":
+			return a.fileType() < b.fileType()
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if direction == "desc" {
+			return less(nodes[j], nodes[i])
+		}
+		return less(nodes[i], nodes[j])
+	})
+
+	return nodes
+}
+
+// matchPatterns reports whether name matches any of case-insensitive glob patterns.
+// Patterns without wildcards match names partially, the way FileStation does.
+func matchPatterns(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if !strings.ContainsAny(pattern, "*?") {
+			pattern = "*" + pattern + "*"
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// paginate returns entries in range of 'offset' and 'limit' parameters, zero limit means all entries.
+func paginate(r *request, entries []map[string]interface{}) (int, int, []map[string]interface{}) {
+	offset := intParam(r, "offset")
+	limit := intParam(r, "limit")
+	if offset < 0 || offset > len(entries) {
+		offset = len(entries)
+	}
+	end := len(entries)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	return offset, len(entries), entries[offset:end]
+}
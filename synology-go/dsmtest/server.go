@@ -0,0 +1,354 @@
+// Package dsmtest provides an in-memory emulator of Synology DSM web API for tests.
+//
+// The emulator implements SYNO.API.Info, SYNO.API.Auth and FileStation APIs
+// on top of a virtual file system, so both the client and provider code
+// can be exercised end-to-end without real hardware:
+//
+//	srv := dsmtest.NewServer()
+//	defer srv.Close()
+//	srv.AddUser("admin", "password")
+//	srv.AddShare("data")
+//
+//	c, _ := client.New(srv.Host(), true)
+//	_ = c.Login("admin", "password", "test")
+package dsmtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// APIInfo describes API advertised by the emulator via SYNO.API.Info.
+type APIInfo struct {
+	Path       string `json:"path"`
+	MinVersion int    `json:"minVersion"`
+	MaxVersion int    `json:"maxVersion"`
+}
+
+// Server is an in-memory DSM emulator served over HTTPS.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	hostname string
+	users    map[string]*user
+	sessions map[string]*session
+	fs       *fileSystem
+	tasks    map[string]*task
+	apis     map[string]api
+}
+
+type user struct {
+	password string
+	otpCode  string
+	devices  map[string]bool
+}
+
+type session struct {
+	account   string
+	name      string
+	synoToken string
+}
+
+// api holds emulated API description and handlers of its methods.
+type api struct {
+	info    APIInfo
+	methods map[string]handlerFunc
+	// public APIs are accessible without session
+	public bool
+}
+
+// handlerFunc processes a single API call.
+// Returned data is sent as 'data' field of successful response.
+// Returned error is sent as 'error' field, *Error values keep their code and details.
+// Returned *rawContent is sent as is, without JSON envelope.
+// Handlers are called with server mutex held.
+type handlerFunc func(s *Server, r *request) (interface{}, error)
+
+// request wraps HTTP request with session information.
+type request struct {
+	*http.Request
+	// w allows handlers to set response headers, e.g. cookies
+	w       http.ResponseWriter
+	session *session
+	sid     string
+}
+
+// Error is an API error returned by emulated handlers.
+type Error struct {
+	Code   int
+	Errors []map[string]interface{}
+}
+
+func (e *Error) Error() string {
+	return "DSM error " + strconv.Itoa(e.Code)
+}
+
+func newError(code int) *Error {
+	return &Error{Code: code}
+}
+
+// Option customizes the emulator.
+type Option func(*Server)
+
+// WithHostname sets hostname reported by the emulator.
+func WithHostname(hostname string) Option {
+	return func(s *Server) {
+		s.hostname = hostname
+	}
+}
+
+// NewServer starts DSM emulator.
+// Caller must call Close when finished.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		hostname: "dsmtest",
+		users:    map[string]*user{},
+		sessions: map[string]*session{},
+		fs:       newFileSystem(),
+		tasks:    map[string]*task{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.registerAPIs()
+	s.Server = httptest.NewTLSServer(s)
+
+	return s
+}
+
+// Host returns address of the emulator in form of 'host:port'.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// AddUser registers account which can log in to the emulator.
+func (s *Server) AddUser(account, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[account] = &user{password: password, devices: map[string]bool{}}
+}
+
+// SetOTPCode enables 2-step verification for account with the only valid code.
+func (s *Server) SetOTPCode(account, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[account]; ok {
+		u.otpCode = code
+	}
+}
+
+// ExpireSessions invalidates all active sessions.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]*session{}
+}
+
+// Sessions returns number of active sessions.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// APIs returns information about all emulated APIs.
+func (s *Server) APIs() map[string]APIInfo {
+	result := map[string]APIInfo{}
+	for name, a := range s.apis {
+		result[name] = a.info
+	}
+
+	return result
+}
+
+// ServeHTTP satisfies http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.handle(w, r)
+	writeResponse(w, data, err)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, newError(101)
+		}
+	}
+
+	apiName, method := r.FormValue("api"), r.FormValue("method")
+	version, err := strconv.Atoi(r.FormValue("version"))
+	if apiName == "" || method == "" || err != nil {
+		return nil, newError(101)
+	}
+
+	a, ok := s.apis[apiName]
+	if !ok {
+		return nil, newError(102)
+	}
+	if "/webapi/"+a.info.Path != r.URL.Path {
+		return nil, newError(102)
+	}
+	handler, ok := a.methods[method]
+	if !ok {
+		return nil, newError(103)
+	}
+	if version < a.info.MinVersion || version > a.info.MaxVersion {
+		return nil, newError(104)
+	}
+
+	req := &request{Request: r, w: w, sid: sessionID(r)}
+	req.session = s.sessions[req.sid]
+	if !a.public && req.session == nil {
+		return nil, newError(119)
+	}
+
+	return handler(s, req)
+}
+
+// sessionID extracts session ID from either '_sid' parameter or 'id' cookie.
+func sessionID(r *http.Request) string {
+	if sid := r.FormValue("_sid"); sid != "" {
+		return sid
+	}
+	if cookie, err := r.Cookie("id"); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
+
+func writeResponse(w http.ResponseWriter, data interface{}, err error) {
+	if raw, ok := data.(*rawContent); ok && err == nil {
+		w.Header().Set("Content-Type", raw.contentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": raw.fileName}))
+		w.Header().Set("Content-Length", strconv.Itoa(len(raw.body)))
+		_, _ = w.Write(raw.body)
+		return
+	}
+
+	response := map[string]interface{}{"success": err == nil}
+	if err != nil {
+		apiErr, ok := err.(*Error)
+		if !ok {
+			apiErr = newError(100)
+		}
+		errObject := map[string]interface{}{"code": apiErr.Code}
+		if len(apiErr.Errors) > 0 {
+			errObject["errors"] = apiErr.Errors
+		}
+		response["error"] = errObject
+	} else if data != nil {
+		response["data"] = data
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// listParam parses DSM list parameter, which is either a JSON array or a single value.
+func listParam(r *request, name string) []string {
+	value := r.FormValue(name)
+	if value == "" {
+		return nil
+	}
+	if strings.HasPrefix(value, "[") {
+		result := []string{}
+		if err := json.Unmarshal([]byte(value), &result); err == nil {
+			return result
+		}
+	}
+
+	return []string{value}
+}
+
+// boolParam parses DSM boolean parameter.
+func boolParam(r *request, name string) bool {
+	switch strings.ToLower(r.FormValue(name)) {
+	case "true", "yes", "1":
+		return true
+	}
+
+	return false
+}
+
+// intParam parses DSM integer parameter, it is zero if missing or invalid.
+func intParam(r *request, name string) int {
+	v, _ := strconv.Atoi(r.FormValue(name))
+
+	return v
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) registerAPIs() {
+	s.apis = map[string]api{
+		"SYNO.API.Info": {
+			info:    APIInfo{Path: "query.cgi", MinVersion: 1, MaxVersion: 1},
+			methods: map[string]handlerFunc{"query": handleAPIInfoQuery},
+			public:  true,
+		},
+		"SYNO.API.Auth": {
+			info: APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 7},
+			methods: map[string]handlerFunc{
+				"login":  handleAuthLogin,
+				"logout": handleAuthLogout,
+			},
+			public: true,
+		},
+		"SYNO.FileStation.Info": {
+			info:    APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{"get": handleFileStationInfoGet},
+		},
+		"SYNO.FileStation.CreateFolder": {
+			info:    APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{"create": handleFileStationCreateFolder},
+		},
+		"SYNO.FileStation.Rename": {
+			info:    APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{"rename": handleFileStationRename},
+		},
+		"SYNO.FileStation.List": {
+			info: APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{
+				"list_share": handleFileStationListShare,
+				"list":       handleFileStationList,
+				"getinfo":    handleFileStationGetInfo,
+			},
+		},
+		"SYNO.FileStation.Upload": {
+			info:    APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{"upload": handleFileStationUpload},
+		},
+		"SYNO.FileStation.Download": {
+			info:    APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{"download": handleFileStationDownload},
+		},
+		"SYNO.FileStation.Delete": {
+			info: APIInfo{Path: "entry.cgi", MinVersion: 1, MaxVersion: 2},
+			methods: map[string]handlerFunc{
+				"start":  handleFileStationDeleteStart,
+				"status": handleFileStationDeleteStatus,
+				"stop":   handleFileStationDeleteStop,
+			},
+		},
+	}
+}
@@ -0,0 +1,547 @@
+package dsmtest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/auth"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/dsmtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *dsmtest.Server {
+	srv := dsmtest.NewServer(dsmtest.WithHostname("test-nas"))
+	t.Cleanup(srv.Close)
+	srv.AddUser("admin", "secret")
+	srv.AddShare("data")
+
+	return srv
+}
+
+func newClient(t *testing.T, srv *dsmtest.Server) client.Client {
+	c, err := client.New(srv.Host(), true, client.WithAPIErrors())
+	require.NoError(t, err)
+	require.NoError(t, c.Login("admin", "secret", "test"))
+
+	return c
+}
+
+func TestServer_login(t *testing.T) {
+	srv := newServer(t)
+	srv.AddUser("otp-user", "secret")
+	srv.SetOTPCode("otp-user", "123456")
+
+	testCases := []struct {
+		name         string
+		user         string
+		password     string
+		options      []client.LoginOption
+		expectedCode int
+	}{
+		{name: "success", user: "admin", password: "secret"},
+		{name: "wrong password", user: "admin", password: "wrong", expectedCode: auth.ErrorIncorrectCredentials},
+		{name: "unknown user", user: "nobody", password: "secret", expectedCode: auth.ErrorIncorrectCredentials},
+		{name: "OTP required", user: "otp-user", password: "secret", expectedCode: auth.ErrorOTPRequired},
+		{
+			name:         "wrong OTP",
+			user:         "otp-user",
+			password:     "secret",
+			options:      []client.LoginOption{client.WithOTPCode("000000")},
+			expectedCode: auth.ErrorOTPFailed,
+		},
+		{
+			name:     "OTP",
+			user:     "otp-user",
+			password: "secret",
+			options:  []client.LoginOption{client.WithOTPCode("123456")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := client.New(srv.Host(), true)
+			require.NoError(t, err)
+
+			err = c.Login(tc.user, tc.password, "test", tc.options...)
+			if tc.expectedCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			loginErr := client.LoginError{}
+			require.ErrorAs(t, err, &loginErr)
+			assert.Equal(t, tc.expectedCode, loginErr.Code)
+		})
+	}
+}
+
+func TestServer_session(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+	assert.Equal(t, 1, srv.Sessions())
+
+	srv.ExpireSessions()
+	response := filestation.FileStationInfoResponse{}
+	require.NoError(t, c.Do(filestation.NewFileStationInfoRequest(2), &response))
+	assert.Equal(t, "test-nas", response.Hostname)
+
+	require.NoError(t, c.Logout())
+	assert.Equal(t, 0, srv.Sessions())
+	err := c.Do(filestation.NewFileStationInfoRequest(2), &response)
+	assert.ErrorIs(t, err, api.ErrSessionExpired)
+}
+
+func TestServer_createFolder(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+
+	testCases := []struct {
+		name          string
+		request       *filestation.CreateFolderRequest
+		expectedPath  string
+		expectedError error
+	}{
+		{
+			name:         "create",
+			request:      filestation.NewCreateFolderRequest(2).WithFolderPath("/data").WithName("folder"),
+			expectedPath: "/data/folder",
+		},
+		{
+			name:          "already exists",
+			request:       filestation.NewCreateFolderRequest(2).WithFolderPath("/data").WithName("folder"),
+			expectedError: api.ErrAlreadyExists,
+		},
+		{
+			name:          "missing parent",
+			request:       filestation.NewCreateFolderRequest(2).WithFolderPath("/data/missing").WithName("folder"),
+			expectedError: api.ErrNotFound,
+		},
+		{
+			name: "force parent",
+			request: filestation.NewCreateFolderRequest(2).
+				WithFolderPath("/data/missing").
+				WithName("folder").
+				WithForceParent(true),
+			expectedPath: "/data/missing/folder",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := filestation.CreateFolderResponse{}
+			err := c.Do(tc.request, &response)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, response.Folders, 1)
+			assert.Equal(t, tc.expectedPath, response.Folders[0].Path)
+			assert.True(t, srv.IsDir(tc.expectedPath))
+		})
+	}
+}
+
+func TestServer_rename(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+	require.NoError(t, srv.MkdirAll("/data/old"))
+
+	response := filestation.FileStationRenameResponse{}
+	require.NoError(t, c.Do(filestation.NewFileStationRenameRequest(2).WithPath("/data/old").WithName("new"), &response))
+	require.Len(t, response.Files, 1)
+	assert.Equal(t, "/data/new", response.Files[0].Path)
+	assert.True(t, srv.IsDir("/data/new"))
+	assert.False(t, srv.Exists("/data/old"))
+
+	err := c.Do(filestation.NewFileStationRenameRequest(2).WithPath("/data/old").WithName("new"), &response)
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestServer_getInfo(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+	require.NoError(t, srv.MkdirAll("/data/folder"))
+	require.NoError(t, srv.WriteFile("/data/folder/file.txt", []byte("content")))
+
+	response := filestation.GetInfoResponse{}
+	request := filestation.NewGetInfoRequest(2).
+		WithPath("/data/folder").
+		WithPath("/data/folder/file.txt").
+		WithPath("/data/missing")
+	require.NoError(t, c.Do(request, &response))
+	assert.Equal(t, []filestation.File{
+		{Path: "/data/folder", Name: "folder", IsDir: true},
+		{Path: "/data/folder/file.txt", Name: "file.txt"},
+		{Path: "/data/missing", Name: "missing", Code: 408},
+	}, response.Files)
+
+	response = filestation.GetInfoResponse{}
+	request = filestation.NewGetInfoRequest(2).
+		WithPath("/data/folder/file.txt").
+		WithAdditional(filestation.AdditionalRealPath, filestation.AdditionalSize, filestation.AdditionalPerm)
+	require.NoError(t, c.Do(request, &response))
+	require.Len(t, response.Files, 1)
+	additional := response.Files[0].Additional
+	require.NotNil(t, additional)
+	assert.Equal(t, "/volume1/data/folder/file.txt", additional.RealPath)
+	assert.Equal(t, int64(7), additional.Size)
+	assert.Equal(t, 644, additional.Perm.Posix)
+}
+
+func TestServer_delete(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+
+	deleteTask := func(p string, recursive bool) client.Task[*filestation.DeleteStatusResponse] {
+		return client.Task[*filestation.DeleteStatusResponse]{
+			Start: func() (api.Request, client.TaskStart) {
+				return filestation.NewDeleteStartRequest(2).WithPath(p).WithRecursive(recursive), &filestation.DeleteStartResponse{}
+			},
+			Status: func(taskID string) (api.Request, *filestation.DeleteStatusResponse) {
+				return filestation.NewDeleteStatusRequest(2, taskID), &filestation.DeleteStatusResponse{}
+			},
+		}
+	}
+	poll := client.WithPollInterval(time.Millisecond, time.Millisecond)
+
+	testCases := []struct {
+		name          string
+		path          string
+		recursive     bool
+		expectedError error
+		expectedCode  int
+	}{
+		{name: "empty folder", path: "/data/empty"},
+		{name: "file", path: "/data/full/file.txt"},
+		{name: "missing", path: "/data/missing", expectedError: api.ErrNotFound},
+		{name: "non-empty folder", path: "/data/full", expectedCode: 900},
+		{name: "recursive", path: "/data/full", recursive: true},
+	}
+
+	require.NoError(t, srv.MkdirAll("/data/empty"))
+	require.NoError(t, srv.WriteFile("/data/full/file.txt", []byte("content")))
+	require.NoError(t, srv.WriteFile("/data/full/other.txt", []byte("content")))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := client.RunTask(context.Background(), c, deleteTask(tc.path, tc.recursive), poll)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			if tc.expectedCode != 0 {
+				synoErr := api.SynologyError{}
+				require.ErrorAs(t, err, &synoErr)
+				assert.Equal(t, tc.expectedCode, synoErr.Code)
+				assert.True(t, srv.Exists(tc.path))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, status.Finished())
+			assert.False(t, srv.Exists(tc.path))
+		})
+	}
+}
+
+func TestServer_listShare(t *testing.T) {
+	srv := newServer(t)
+	srv.AddShare("backup")
+	c := newClient(t, srv)
+
+	response := filestation.ListShareResponse{}
+	request := filestation.NewListShareRequest(2).
+		WithSortDirection(filestation.SortDescending).
+		WithAdditional(filestation.AdditionalRealPath, filestation.AdditionalPerm, filestation.AdditionalVolumeStatus)
+	require.NoError(t, c.Do(request, &response))
+	assert.Equal(t, 2, response.Total)
+	require.Len(t, response.Shares, 2)
+	assert.Equal(t, "/data", response.Shares[0].Path)
+	assert.Equal(t, "/backup", response.Shares[1].Path)
+
+	additional := response.Shares[0].Additional
+	require.NotNil(t, additional)
+	assert.Equal(t, "/volume1/data", additional.RealPath)
+	assert.Equal(t, "RW", additional.Perm.ShareRight)
+	require.NotNil(t, additional.VolumeStatus)
+	assert.Positive(t, additional.VolumeStatus.TotalSpace)
+}
+
+func TestServer_list(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+	require.NoError(t, srv.MkdirAll("/data/folder/dir"))
+	require.NoError(t, srv.WriteFile("/data/folder/b.txt", []byte("12345")))
+	require.NoError(t, srv.WriteFile("/data/folder/a.log", []byte("123")))
+	require.NoError(t, srv.WriteFile("/data/folder/c.TXT", []byte("1")))
+
+	list := func(configure func(r *filestation.ListRequest)) []string {
+		t.Helper()
+
+		files, err := client.NewPager[filestation.File](c, func(offset, limit int) (api.Request, client.Page[filestation.File]) {
+			r := filestation.NewListRequest(2, "/data/folder").WithOffset(offset).WithLimit(limit)
+			configure(r)
+			return r, &filestation.ListResponse{}
+		}).WithPageSize(2).All(context.Background())
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, f := range files {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+
+	testCases := []struct {
+		name          string
+		configure     func(r *filestation.ListRequest)
+		expectedNames []string
+	}{
+		{
+			name:          "all",
+			configure:     func(r *filestation.ListRequest) {},
+			expectedNames: []string{"a.log", "b.txt", "c.TXT", "dir"},
+		},
+		{
+			name: "sort by size descending",
+			configure: func(r *filestation.ListRequest) {
+				r.WithSortBy(filestation.SortBySize).WithSortDirection(filestation.SortDescending)
+			},
+			expectedNames: []string{"b.txt", "a.log", "c.TXT", "dir"},
+		},
+		{
+			name:          "pattern",
+			configure:     func(r *filestation.ListRequest) { r.WithPattern("*.txt") },
+			expectedNames: []string{"b.txt", "c.TXT"},
+		},
+		{
+			name:          "partial patterns",
+			configure:     func(r *filestation.ListRequest) { r.WithPattern("log").WithPattern("di") },
+			expectedNames: []string{"a.log", "dir"},
+		},
+		{
+			name:          "directories",
+			configure:     func(r *filestation.ListRequest) { r.WithFileType(filestation.FileTypeDir) },
+			expectedNames: []string{"dir"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNames, list(tc.configure))
+		})
+	}
+
+	response := filestation.ListResponse{}
+	request := filestation.NewListRequest(2, "/data/folder").
+		WithPattern("b.txt").
+		WithAdditional(filestation.AdditionalSize, filestation.AdditionalOwner, filestation.AdditionalTime, filestation.AdditionalType)
+	require.NoError(t, c.Do(request, &response))
+	require.Len(t, response.Files, 1)
+	additional := response.Files[0].Additional
+	require.NotNil(t, additional)
+	assert.Equal(t, int64(5), additional.Size)
+	assert.Equal(t, "admin", additional.Owner.User)
+	assert.Equal(t, "TXT", additional.Type)
+	assert.WithinDuration(t, time.Now(), additional.Time.MTime, time.Minute)
+
+	err := c.Do(filestation.NewListRequest(2, "/data/missing"), &response)
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestServer_upload(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+
+	upload := func(folderPath, content string) *filestation.UploadRequest {
+		return filestation.NewUploadRequest(2, folderPath, "file.txt", strings.NewReader(content))
+	}
+
+	testCases := []struct {
+		name            string
+		request         *filestation.UploadRequest
+		expectedPath    string
+		expectedContent string
+		expectedSkipped bool
+		expectedError   error
+		expectedCode    int
+	}{
+		{
+			name:            "create",
+			request:         upload("/data", "first"),
+			expectedPath:    "/data/file.txt",
+			expectedContent: "first",
+		},
+		{
+			name:          "existing file",
+			request:       upload("/data", "second"),
+			expectedError: api.ErrAlreadyExists,
+		},
+		{
+			name:            "skip existing file",
+			request:         upload("/data", "second").WithOverwrite(false),
+			expectedPath:    "/data/file.txt",
+			expectedContent: "first",
+			expectedSkipped: true,
+		},
+		{
+			name:            "overwrite",
+			request:         upload("/data", "second").WithOverwrite(true),
+			expectedPath:    "/data/file.txt",
+			expectedContent: "second",
+		},
+		{
+			name:          "missing parent",
+			request:       upload("/data/missing", "content"),
+			expectedError: api.ErrNotFound,
+		},
+		{
+			name:            "create parents",
+			request:         upload("/data/missing", "content").WithCreateParents(true),
+			expectedPath:    "/data/missing/file.txt",
+			expectedContent: "content",
+		},
+		{
+			name:         "unknown size",
+			request:      filestation.NewUploadRequest(2, "/data", "file.txt", io.MultiReader(strings.NewReader("content"))),
+			expectedCode: 1800,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := filestation.UploadResponse{}
+			err := c.Do(tc.request, &response)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			if tc.expectedCode != 0 {
+				synoErr := api.SynologyError{}
+				require.ErrorAs(t, err, &synoErr)
+				assert.Equal(t, tc.expectedCode, synoErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSkipped, response.Skipped)
+			content, err := srv.ReadFile(tc.expectedPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, string(content))
+		})
+	}
+
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, c.Do(upload("/data", "content").WithOverwrite(true).WithMTime(mtime), &filestation.UploadResponse{}))
+	response := filestation.GetInfoResponse{}
+	require.NoError(t, c.Do(filestation.NewGetInfoRequest(2).WithPath("/data/file.txt").WithAdditional(filestation.AdditionalTime), &response))
+	require.Len(t, response.Files, 1)
+	require.NotNil(t, response.Files[0].Additional)
+	assert.True(t, mtime.Equal(response.Files[0].Additional.Time.MTime))
+}
+
+func TestServer_download(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv)
+	require.NoError(t, srv.WriteFile("/data/app/config.yml", []byte("key: value")))
+	require.NoError(t, srv.WriteFile("/data/app/keys/id.pub", []byte("ssh-ed25519 AAAA")))
+	require.NoError(t, srv.WriteFile("/data/notes.txt", []byte("notes")))
+
+	testCases := []struct {
+		name             string
+		paths            []string
+		limit            int64
+		expectedFileName string
+		expectedContent  string
+		expectedEntries  map[string]string
+		expectedError    error
+	}{
+		{
+			name:             "file",
+			paths:            []string{"/data/app/config.yml"},
+			expectedFileName: "config.yml",
+			expectedContent:  "key: value",
+		},
+		{
+			name:             "folder",
+			paths:            []string{"/data/app"},
+			expectedFileName: "app.zip",
+			expectedEntries: map[string]string{
+				"app/":            "",
+				"app/config.yml":  "key: value",
+				"app/keys/":       "",
+				"app/keys/id.pub": "ssh-ed25519 AAAA",
+			},
+		},
+		{
+			name:             "multiple files",
+			paths:            []string{"/data/app/config.yml", "/data/notes.txt"},
+			expectedFileName: "download.zip",
+			expectedEntries: map[string]string{
+				"config.yml": "key: value",
+				"notes.txt":  "notes",
+			},
+		},
+		{
+			name:          "missing file",
+			paths:         []string{"/data/missing.txt"},
+			expectedError: api.ErrNotFound,
+		},
+		{
+			name:          "content too large",
+			paths:         []string{"/data/app/config.yml"},
+			limit:         5,
+			expectedError: filestation.ErrContentTooLarge,
+		},
+		{
+			name:             "content within limit",
+			paths:            []string{"/data/notes.txt"},
+			limit:            5,
+			expectedFileName: "notes.txt",
+			expectedContent:  "notes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := filestation.NewDownloadRequest(2)
+			for _, p := range tc.paths {
+				request.WithPath(p)
+			}
+			buf := &bytes.Buffer{}
+			response := filestation.DownloadResponse{Writer: buf, Limit: tc.limit}
+			err := c.Do(request, &response)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFileName, response.FileName)
+			assert.Equal(t, int64(buf.Len()), response.Size)
+			assert.Equal(t, tc.expectedEntries != nil, response.Zipped())
+			if tc.expectedEntries == nil {
+				assert.Equal(t, tc.expectedContent, buf.String())
+				return
+			}
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			entries := map[string]string{}
+			for _, f := range zr.File {
+				r, err := f.Open()
+				require.NoError(t, err)
+				content, err := io.ReadAll(r)
+				require.NoError(t, err)
+				entries[f.Name] = string(content)
+			}
+			assert.Equal(t, tc.expectedEntries, entries)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Masked replaces values of secret parameters in request logs.
+const Masked = "***"
+
+// secretParams are request parameters holding secrets.
+var secretParams = map[string]bool{
+	"passwd":    true,
+	"otp_code":  true,
+	"_sid":      true,
+	"device_id": true,
+}
+
+// secretHeaders are request and response headers holding secrets, in canonical form.
+var secretHeaders = map[string]bool{
+	"X-Syno-Token": true,
+	"Cookie":       true,
+	"Set-Cookie":   true,
+}
+
+// IsSecretParam reports whether request parameter holds a secret.
+// Values of such parameters are masked in request logs and redacted in recorded cassettes.
+func IsSecretParam(name string) bool {
+	return secretParams[name]
+}
+
+// IsSecretHeader reports whether request or response header holds a secret, the name is case-insensitive.
+// Values of such headers are masked in request logs and redacted in recorded cassettes.
+func IsSecretHeader(name string) bool {
+	return secretHeaders[http.CanonicalHeaderKey(name)]
+}
+
+// RequestLog describes a single HTTP exchange with remote instance.
+type RequestLog struct {
+	// API, Method and Version identify called API.
+	API     string
+	Method  string
+	Version int
+
+	// HTTPMethod and Path are HTTP method and URL path of the request.
+	HTTPMethod string
+	Path       string
+
+	// Params are request parameters, except file parts, with values of secrets replaced by Masked.
+	Params url.Values
+
+	// Header holds request headers with values of secrets replaced by Masked.
+	Header http.Header
+
+	// StatusCode is HTTP status of the response, zero if no response was received.
+	StatusCode int
+
+	// ResponseSize is the number of bytes of response body read.
+	ResponseSize int64
+
+	// Duration is the time elapsed from sending the request to reading the response.
+	Duration time.Duration
+
+	// UnknownFields are names of response fields not known to response object.
+	UnknownFields []string
+
+	// ErrorCode is the code of API error, zero if the request succeeded or failed before decoding the response.
+	ErrorCode int
+
+	// Err is transport or decoding error, if any.
+	Err error
+}
+
+// RequestLogger is called after every HTTP exchange with remote instance,
+// including API discovery, authentication and retries.
+// ctx is the context of the request.
+type RequestLogger func(ctx context.Context, entry RequestLog)
+
+// WithRequestLogger sets function to log requests to remote instance with.
+func WithRequestLogger(logger RequestLogger) Option {
+	return func(c *client) {
+		c.requestLogger = logger
+	}
+}
+
+// newRequestLog returns log entry for the request with params and header, masking secrets.
+func newRequestLog(httpMethod, path string, params url.Values, header http.Header) RequestLog {
+	entry := RequestLog{
+		API:        params.Get("api"),
+		Method:     params.Get("method"),
+		HTTPMethod: httpMethod,
+		Path:       path,
+		Params:     make(url.Values, len(params)),
+		Header:     make(http.Header, len(header)),
+	}
+	entry.Version, _ = strconv.Atoi(params.Get("version"))
+	for k, values := range params {
+		masked := make([]string, len(values))
+		for i, v := range values {
+			if IsSecretParam(k) && v != "" {
+				v = Masked
+			}
+			masked[i] = v
+		}
+		entry.Params[k] = masked
+	}
+	for k, values := range header {
+		masked := make([]string, len(values))
+		for i, v := range values {
+			if IsSecretHeader(k) && v != "" {
+				v = Masked
+			}
+			masked[i] = v
+		}
+		entry.Header[k] = masked
+	}
+
+	return entry
+}
+
+// countingReader counts bytes read from underlying reader.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/dsmtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestLogger(t *testing.T) {
+	srv := dsmtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddUser("api-client", "password")
+	srv.AddShare("data")
+
+	mu := sync.Mutex{}
+	entries := []RequestLog{}
+	logger := func(ctx context.Context, entry RequestLog) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, entry)
+	}
+	c, err := New(srv.Host(), true, WithRequestLogger(logger), WithSessionTransport(SessionTransportQuery))
+	require.NoError(t, err)
+	require.NoError(t, c.Login("api-client", "password", "webui"))
+	require.NoError(t, c.Do(filestation.NewFileStationInfoRequest(2), &filestation.FileStationInfoResponse{}))
+	require.NoError(t, c.Do(
+		filestation.NewCreateFolderRequest(2).WithFolderPath("/missing").WithName("folder"),
+		&filestation.CreateFolderResponse{},
+	))
+
+	require.Len(t, entries, 4)
+	for _, entry := range entries {
+		assert.Equal(t, http.StatusOK, entry.StatusCode)
+		assert.Positive(t, entry.ResponseSize)
+		assert.Positive(t, entry.Duration)
+		assert.NoError(t, entry.Err)
+	}
+
+	assert.Equal(t, "SYNO.API.Info", entries[0].API)
+	assert.Equal(t, "/webapi/query.cgi", entries[0].Path)
+
+	login := entries[1]
+	assert.Equal(t, "SYNO.API.Auth", login.API)
+	assert.Equal(t, "login", login.Method)
+	assert.Equal(t, http.MethodPost, login.HTTPMethod)
+	assert.Equal(t, "api-client", login.Params.Get("account"))
+	assert.Equal(t, Masked, login.Params.Get("passwd"))
+	for _, name := range []string{"otp_code", "device_name", "device_id"} {
+		assert.NotContains(t, login.Params, name, "unset optional parameters are not sent")
+	}
+
+	info := entries[2]
+	assert.Equal(t, "SYNO.FileStation.Info", info.API)
+	assert.Equal(t, "get", info.Method)
+	assert.Equal(t, 2, info.Version)
+	assert.Equal(t, Masked, info.Params.Get("_sid"))
+	assert.Equal(t, Masked, info.Header.Get("X-SYNO-TOKEN"))
+	assert.Zero(t, info.ErrorCode)
+
+	assert.Equal(t, "SYNO.FileStation.CreateFolder", entries[3].API)
+	assert.Equal(t, 1100, entries[3].ErrorCode)
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldTag holds options of `synology` struct tag.
+type fieldTag struct {
+	// name of URL parameter, defaults to lowercased field name
+	name string
+	// explicit is set if the field has `synology` tag
+	explicit bool
+	// omitEmpty skips zero values, empty slices and maps
+	omitEmpty bool
+	// json encodes value as JSON, e.g. strings are quoted
+	json bool
+	// comma encodes slice as comma-separated list instead of JSON array
+	comma bool
+}
+
+// parseFieldTag returns options of the field, reporting false if the field must be skipped.
+func parseFieldTag(field reflect.StructField) (fieldTag, bool) {
+	tag := fieldTag{name: strings.ToLower(field.Name)}
+	value, ok := field.Tag.Lookup("synology")
+	if !ok {
+		return tag, true
+	}
+	if value == "-" {
+		return tag, false
+	}
+
+	tag.explicit = true
+	parts := strings.Split(value, ",")
+	if parts[0] != "" {
+		tag.name = parts[0]
+	}
+	for _, option := range parts[1:] {
+		switch option {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "json":
+			tag.json = true
+		case "comma":
+			tag.comma = true
+		}
+	}
+
+	return tag, true
+}
+
+// marshalURL encodes request into URL parameters according to `synology` tags of its fields.
+//
+// Tag format is `synology:"name,option,..."`, name defaults to lowercased field name, "-" skips the field.
+// Supported options are:
+//   - omitempty: skip zero values, empty slices and maps;
+//   - json: encode value as JSON, e.g. strings are quoted as some APIs expect;
+//   - comma: encode slice as comma-separated list instead of JSON array.
+//
+// Only exported or explicitly tagged fields are encoded.
+// Fields of embedded structs are flattened, but only explicitly tagged ones are encoded,
+// so embedded structs can hold unexported state.
+// Nil pointers and interfaces are skipped, so pointers can be used for optional parameters.
+// Slices, arrays, maps and nested structs are encoded as JSON.
+// Values of unsupported kinds, e.g. channels or functions, result in error.
+func marshalURL(r interface{}) (url.Values, error) {
+	v := reflect.Indirect(reflect.ValueOf(r))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected type struct, got %T", r)
+	}
+
+	ret := url.Values{}
+	if err := marshalStruct(ret, v, false); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// marshalStruct adds encoded fields of struct v to ret.
+// embedded is set for embedded structs to encode only explicitly tagged fields.
+func marshalStruct(ret url.Values, v reflect.Value, embedded bool) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := parseFieldTag(field)
+		if !ok {
+			continue
+		}
+		fieldValue := v.Field(i)
+
+		if field.Anonymous && !tag.explicit {
+			embeddedValue, ok := indirect(fieldValue)
+			if !ok {
+				continue
+			}
+			if embeddedValue.Kind() == reflect.Struct {
+				if err := marshalStruct(ret, embeddedValue, true); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !tag.explicit && (embedded || !field.IsExported()) {
+			continue
+		}
+
+		value, ok, err := marshalValue(fieldValue, tag)
+		if err != nil {
+			return fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+		if ok {
+			ret.Add(tag.name, value)
+		}
+	}
+
+	return nil
+}
+
+// marshalValue encodes a single value, reporting false if it must be skipped.
+func marshalValue(v reflect.Value, tag fieldTag) (string, bool, error) {
+	v, ok := indirect(v)
+	if !ok {
+		return "", false, nil
+	}
+	if tag.omitEmpty && isEmptyValue(v) {
+		return "", false, nil
+	}
+	if tag.json {
+		b, err := appendJSON(nil, v)
+		return string(b), true, err
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if tag.comma {
+			items := make([]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				item, ok := indirect(v.Index(i))
+				if !ok {
+					return "", false, fmt.Errorf("nil item %d in comma-separated list", i)
+				}
+				s, err := marshalScalar(item)
+				if err != nil {
+					return "", false, fmt.Errorf("item %d of comma-separated list: %w", i, err)
+				}
+				items = append(items, s)
+			}
+			return strings.Join(items, ","), true, nil
+		}
+		fallthrough
+	case reflect.Map, reflect.Struct:
+		b, err := appendJSON(nil, v)
+		return string(b), true, err
+	}
+
+	s, err := marshalScalar(v)
+
+	return s, true, err
+}
+
+// marshalScalar encodes value of basic kind as is.
+func marshalScalar(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported kind %s", v.Kind())
+}
+
+// appendJSON appends JSON representation of v to buf.
+//
+// Unlike encoding/json, it can encode values of unexported fields,
+// and struct fields are named the same way as URL parameters.
+func appendJSON(buf []byte, v reflect.Value) ([]byte, error) {
+	v, ok := indirect(v)
+	if !ok {
+		return append(buf, "null"...), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return appendJSONString(buf, v.String()), nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return nil, fmt.Errorf("unsupported float value %v", v.Float())
+		}
+		fallthrough
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s, err := marshalScalar(v)
+		return append(buf, s...), err
+	case reflect.Slice, reflect.Array:
+		buf = append(buf, '[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = appendJSON(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+	case reflect.Map:
+		return appendJSONMap(buf, v)
+	case reflect.Struct:
+		buf = append(buf, '{')
+		buf, _, err := appendJSONFields(buf, v, true)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, '}'), nil
+	}
+
+	return nil, fmt.Errorf("unsupported kind %s", v.Kind())
+}
+
+// appendJSONMap appends JSON object with sorted keys of map v to buf.
+func appendJSONMap(buf []byte, v reflect.Value) ([]byte, error) {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := marshalScalar(iter.Key())
+		if err != nil {
+			return nil, fmt.Errorf("map key: %w", err)
+		}
+		keys = append(keys, key)
+		values[key] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	buf = append(buf, '{')
+	for i, key := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(appendJSONString(buf, key), ':')
+		var err error
+		if buf, err = appendJSON(buf, values[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(buf, '}'), nil
+}
+
+// appendJSONFields appends fields of struct v to JSON object in buf, flattening embedded structs.
+// first reports whether no fields have been written to the object yet.
+func appendJSONFields(buf []byte, v reflect.Value, first bool) ([]byte, bool, error) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := parseFieldTag(field)
+		if !ok {
+			continue
+		}
+		fieldValue, ok := indirect(v.Field(i))
+		if !ok || (tag.omitEmpty && isEmptyValue(fieldValue)) {
+			continue
+		}
+		if field.Anonymous && !tag.explicit && fieldValue.Kind() == reflect.Struct {
+			var err error
+			if buf, first, err = appendJSONFields(buf, fieldValue, first); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		if !tag.explicit && !field.IsExported() {
+			continue
+		}
+
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(appendJSONString(buf, tag.name), ':')
+		var err error
+		if buf, err = appendJSON(buf, fieldValue); err != nil {
+			return nil, false, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+	}
+
+	return buf, first, nil
+}
+
+// appendJSONString appends quoted JSON string to buf.
+// HTML characters are not escaped, since the value is not embedded into HTML.
+func appendJSONString(buf []byte, s string) []byte {
+	b := bytes.Buffer{}
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	// encoding a string never fails
+	_ = enc.Encode(s)
+
+	return append(buf, bytes.TrimSuffix(b.Bytes(), []byte("\n"))...)
+}
+
+// indirect dereferences pointers and interfaces, reporting false if nil is met.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+
+	return v, true
+}
+
+// isEmptyValue reports whether v is a zero value, empty slice, array, map or string.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
@@ -0,0 +1,358 @@
+package client
+
+import (
+	"math"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestMarshalURL(t *testing.T) {
+	type embeddedStruct struct {
+		EmbeddedString string `synology:"embedded_string"`
+		EmbeddedInt    int    `synology:"embedded_int"`
+		untagged       int
+	}
+
+	testCases := []struct {
+		name     string
+		in       interface{}
+		expected url.Values
+	}{
+		{
+			name: "scalar types",
+			in: struct {
+				Name    string `synology:"name"`
+				ID      int    `synology:"id"`
+				Enabled bool   `synology:"enabled"`
+			}{
+				Name:    "name value",
+				ID:      2,
+				Enabled: true,
+			},
+			expected: url.Values{
+				"name":    []string{"name value"},
+				"id":      []string{"2"},
+				"enabled": []string{"true"},
+			},
+		},
+		{
+			name: "slice types",
+			in: struct {
+				Names []string `synology:"names"`
+				IDs   []int    `synology:"ids"`
+			}{
+				Names: []string{"value 1", "value 2"},
+				IDs:   []int{1, 2, 3},
+			},
+			expected: url.Values{
+				"names": []string{"[\"value 1\",\"value 2\"]"},
+				"ids":   []string{"[1,2,3]"},
+			},
+		},
+		{
+			name: "embedded struct",
+			in: struct {
+				embeddedStruct
+				Name string `synology:"name"`
+			}{
+				embeddedStruct: embeddedStruct{
+					EmbeddedString: "my string",
+					EmbeddedInt:    5,
+					untagged:       7,
+				},
+				Name: "field name",
+			},
+			expected: url.Values{
+				"name":            []string{"field name"},
+				"embedded_string": []string{"my string"},
+				"embedded_int":    []string{"5"},
+			},
+		},
+		{
+			name: "unexported field without tag",
+			in: struct {
+				Name       string `synology:"name"`
+				ID         int    `synology:"id"`
+				unexported string
+			}{
+				Name:       "name value",
+				ID:         2,
+				unexported: "must be skipped",
+			},
+			expected: url.Values{
+				"name": []string{"name value"},
+				"id":   []string{"2"},
+			},
+		},
+		{
+			name: "unexported field with tag",
+			in: struct {
+				Name       string `synology:"name"`
+				ID         int    `synology:"id"`
+				unexported string `synology:"unexported"`
+			}{
+				Name:       "name value",
+				ID:         2,
+				unexported: "with explicit tag",
+			},
+			expected: url.Values{
+				"name":       []string{"name value"},
+				"id":         []string{"2"},
+				"unexported": []string{"with explicit tag"},
+			},
+		},
+		{
+			name: "integer, unsigned and float kinds",
+			in: struct {
+				Size    int64   `synology:"size"`
+				Offset  uint    `synology:"offset"`
+				Small   int8    `synology:"small"`
+				Ratio   float64 `synology:"ratio"`
+				Percent float32 `synology:"percent"`
+			}{
+				Size:    1 << 40,
+				Offset:  7,
+				Small:   -3,
+				Ratio:   0.25,
+				Percent: 12.5,
+			},
+			expected: url.Values{
+				"size":    []string{"1099511627776"},
+				"offset":  []string{"7"},
+				"small":   []string{"-3"},
+				"ratio":   []string{"0.25"},
+				"percent": []string{"12.5"},
+			},
+		},
+		{
+			name: "more slice types",
+			in: struct {
+				Flags  []bool    `synology:"flags"`
+				Sizes  []int64   `synology:"sizes"`
+				Empty  []string  `synology:"empty"`
+				Quoted []string  `synology:"quoted"`
+				Array  [2]string `synology:"array"`
+			}{
+				Flags:  []bool{true, false},
+				Sizes:  []int64{1, 2},
+				Quoted: []string{`a "b" <c>`},
+				Array:  [2]string{"x", "y"},
+			},
+			expected: url.Values{
+				"flags":  []string{"[true,false]"},
+				"sizes":  []string{"[1,2]"},
+				"empty":  []string{"[]"},
+				"quoted": []string{`["a \"b\" <c>"]`},
+				"array":  []string{`["x","y"]`},
+			},
+		},
+		{
+			name: "comma-separated lists",
+			in: struct {
+				Additional []string `synology:"additional,comma"`
+				IDs        []int    `synology:"ids,comma"`
+				Empty      []string `synology:"empty,comma"`
+			}{
+				Additional: []string{"real_path", "size"},
+				IDs:        []int{1, 2},
+			},
+			expected: url.Values{
+				"additional": []string{"real_path,size"},
+				"ids":        []string{"1,2"},
+				"empty":      []string{""},
+			},
+		},
+		{
+			name: "pointers",
+			in: struct {
+				Name    *string `synology:"name"`
+				Limit   *int    `synology:"limit"`
+				Enabled *bool   `synology:"enabled"`
+				Unset   *string `synology:"unset"`
+			}{
+				Name:    pointer("name value"),
+				Limit:   pointer(0),
+				Enabled: pointer(false),
+			},
+			expected: url.Values{
+				"name":    []string{"name value"},
+				"limit":   []string{"0"},
+				"enabled": []string{"false"},
+			},
+		},
+		{
+			name: "omitempty",
+			in: struct {
+				Name     string            `synology:"name,omitempty"`
+				Limit    int               `synology:"limit,omitempty"`
+				Enabled  bool              `synology:"enabled,omitempty"`
+				Paths    []string          `synology:"paths,omitempty"`
+				Extra    map[string]string `synology:"extra,omitempty"`
+				Offset   *int              `synology:"offset,omitempty"`
+				Set      string            `synology:"set,omitempty"`
+				Explicit int               `synology:"explicit"`
+			}{
+				Offset: pointer(0),
+				Set:    "value",
+			},
+			expected: url.Values{
+				"set":      []string{"value"},
+				"explicit": []string{"0"},
+			},
+		},
+		{
+			name: "JSON values",
+			in: struct {
+				Name    string   `synology:"name,json"`
+				Enabled bool     `synology:"enabled,json"`
+				Paths   []string `synology:"paths,json"`
+				Nested  struct {
+					Name  string `synology:"name"`
+					Owner string `synology:"owner,omitempty"`
+					Size  *int64 `synology:"size"`
+					Label string
+				} `synology:"nested"`
+				Options map[string]interface{} `synology:"options"`
+			}{
+				Name:    "folder",
+				Enabled: true,
+				Paths:   []string{"/data"},
+				Nested: struct {
+					Name  string `synology:"name"`
+					Owner string `synology:"owner,omitempty"`
+					Size  *int64 `synology:"size"`
+					Label string
+				}{
+					Name:  "nested",
+					Label: "label",
+				},
+				Options: map[string]interface{}{
+					"recursive": true,
+					"depth":     2,
+					"mode":      "fast",
+				},
+			},
+			expected: url.Values{
+				"name":    []string{`"folder"`},
+				"enabled": []string{"true"},
+				"paths":   []string{`["/data"]`},
+				"nested":  []string{`{"name":"nested","label":"label"}`},
+				"options": []string{`{"depth":2,"mode":"fast","recursive":true}`},
+			},
+		},
+		{
+			name: "embedded pointer and nested embedded structs",
+			in: struct {
+				*embeddedStruct
+				Name string `synology:"name"`
+			}{
+				embeddedStruct: &embeddedStruct{EmbeddedInt: 1},
+				Name:           "field name",
+			},
+			expected: url.Values{
+				"name":            []string{"field name"},
+				"embedded_string": []string{""},
+				"embedded_int":    []string{"1"},
+			},
+		},
+		{
+			name: "nil embedded pointer",
+			in: struct {
+				*embeddedStruct
+				Name string `synology:"name"`
+			}{
+				Name: "field name",
+			},
+			expected: url.Values{
+				"name": []string{"field name"},
+			},
+		},
+		{
+			name: "skipped fields",
+			in: struct {
+				Name    string `synology:"name"`
+				Skipped string `synology:"-"`
+				Default string `synology:",omitempty"`
+			}{
+				Name:    "name value",
+				Skipped: "value",
+				Default: "default name",
+			},
+			expected: url.Values{
+				"name":    []string{"name value"},
+				"default": []string{"default name"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := marshalURL(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestMarshalURL_errors(t *testing.T) {
+	type channelRequest struct {
+		Events chan int `synology:"events"`
+	}
+
+	testCases := []struct {
+		name        string
+		in          interface{}
+		expectedErr string
+	}{
+		{
+			name:        "not a struct",
+			in:          "value",
+			expectedErr: "expected type struct, got string",
+		},
+		{
+			name:        "channel",
+			in:          channelRequest{},
+			expectedErr: "field Events of client.channelRequest: unsupported kind chan",
+		},
+		{
+			name: "function in JSON",
+			in: struct {
+				Options map[string]interface{} `synology:"options"`
+			}{
+				Options: map[string]interface{}{"callback": func() {}},
+			},
+			expectedErr: "unsupported kind func",
+		},
+		{
+			name: "nested list in comma-separated list",
+			in: struct {
+				Items [][]string `synology:"items,comma"`
+			}{
+				Items: [][]string{{"a"}},
+			},
+			expectedErr: "item 0 of comma-separated list: unsupported kind slice",
+		},
+		{
+			name: "NaN in JSON",
+			in: struct {
+				Ratios []float64 `synology:"ratios"`
+			}{
+				Ratios: []float64{math.NaN()},
+			},
+			expectedErr: "unsupported float value NaN",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := marshalURL(tc.in)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// DefaultPageSize is the default number of items requested per page.
+const DefaultPageSize = 500
+
+// Page is a response of list API paged with offset and limit.
+type Page[Item any] interface {
+	api.Response
+
+	// PageItems returns items of the page.
+	PageItems() []Item
+
+	// PageTotal returns total number of items available on remote instance.
+	PageTotal() int
+}
+
+// PageRequestFunc returns request for a page starting at offset with at most limit items
+// along with empty response to decode the page into.
+type PageRequestFunc[Item any] func(offset, limit int) (api.Request, Page[Item])
+
+// Pager enumerates all items of list API by requesting pages with advancing offsets.
+type Pager[Item any] struct {
+	client   Client
+	request  PageRequestFunc[Item]
+	pageSize int
+}
+
+// NewPager creates pager requesting pages built by request function.
+func NewPager[Item any](c Client, request PageRequestFunc[Item]) *Pager[Item] {
+	return &Pager[Item]{
+		client:   c,
+		request:  request,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// WithPageSize sets the number of items requested per page.
+// Non-positive value resets it to DefaultPageSize.
+func (p *Pager[Item]) WithPageSize(size int) *Pager[Item] {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	p.pageSize = size
+
+	return p
+}
+
+// Each calls fn for every item in order, requesting next page once items of the current one are exhausted.
+//
+// Enumeration stops when fn returns false, all items reported by remote instance are seen or a page is empty,
+// so items added or removed during enumeration can be missed or reported twice.
+// API failures are returned as errors regardless of WithAPIErrors option.
+func (p *Pager[Item]) Each(ctx context.Context, fn func(item Item) bool) error {
+	for offset := 0; ; {
+		r, page := p.request(offset, p.pageSize)
+		if err := p.client.DoContext(ctx, r, page); err != nil {
+			return err
+		}
+		if !page.Success() {
+			return page.GetError()
+		}
+
+		items := page.PageItems()
+		for _, item := range items {
+			if !fn(item) {
+				return nil
+			}
+		}
+		offset += len(items)
+		if len(items) == 0 || offset >= page.PageTotal() {
+			return nil
+		}
+	}
+}
+
+// All returns all items.
+func (p *Pager[Item]) All(ctx context.Context) ([]Item, error) {
+	items := []Item{}
+	err := p.Each(ctx, func(item Item) bool {
+		items = append(items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
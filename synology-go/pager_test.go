@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listRequest struct {
+	Offset int `synology:"offset"`
+	Limit  int `synology:"limit"`
+}
+
+type listResponse struct {
+	testResponse
+
+	Items []int `synology:"items"`
+	Total int   `synology:"total"`
+}
+
+var _ Page[int] = (*listResponse)(nil)
+
+func (r *listResponse) PageItems() []int {
+	return r.Items
+}
+
+func (r *listResponse) PageTotal() int {
+	return r.Total
+}
+
+// newListServer serves total items with offset and limit, counting requests.
+func newListServer(t *testing.T, total int, requests *int) string {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if offset < 0 {
+			fmt.Fprint(w, `{"success":false,"error":{"code":101}}`)
+			return
+		}
+		items := []int{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, i)
+		}
+		data, _ := json.Marshal(map[string]interface{}{"items": items, "total": total})
+		fmt.Fprintf(w, `{"success":true,"data":%s}`, data)
+	})
+}
+
+func listPage(offset, limit int) (api.Request, Page[int]) {
+	return listRequest{Offset: offset, Limit: limit}, &listResponse{}
+}
+
+func TestPager_All(t *testing.T) {
+	testCases := []struct {
+		name             string
+		total            int
+		pageSize         int
+		expectedRequests int
+	}{
+		{name: "empty list", total: 0, pageSize: 10, expectedRequests: 1},
+		{name: "single page", total: 5, pageSize: 10, expectedRequests: 1},
+		{name: "exact pages", total: 20, pageSize: 10, expectedRequests: 2},
+		{name: "partial last page", total: 25, pageSize: 10, expectedRequests: 3},
+		{name: "default page size", total: 1200, pageSize: 0, expectedRequests: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			c, err := New(newListServer(t, tc.total, &requests), true)
+			require.NoError(t, err)
+
+			items, err := NewPager[int](c, listPage).WithPageSize(tc.pageSize).All(context.Background())
+			require.NoError(t, err)
+			require.Len(t, items, tc.total)
+			for i, item := range items {
+				assert.Equal(t, i, item)
+			}
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+func TestPager_Each(t *testing.T) {
+	requests := 0
+	c, err := New(newListServer(t, 100, &requests), true)
+	require.NoError(t, err)
+
+	seen := []int{}
+	err = NewPager[int](c, listPage).WithPageSize(10).Each(context.Background(), func(item int) bool {
+		seen = append(seen, item)
+		return item < 14
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, seen)
+	assert.Equal(t, 2, requests, "pages after early stop must not be requested")
+}
+
+func TestPager_errors(t *testing.T) {
+	requests := 0
+	c, err := New(newListServer(t, 100, &requests), true)
+	require.NoError(t, err)
+
+	_, err = NewPager[int](c, func(offset, limit int) (api.Request, Page[int]) {
+		return listRequest{Offset: offset - 1, Limit: limit}, &listResponse{}
+	}).All(context.Background())
+	synoErr := api.SynologyError{}
+	require.ErrorAs(t, err, &synoErr)
+	assert.Equal(t, 101, synoErr.Code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewPager[int](c, listPage).All(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the rate of requests sent to remote instance with a token bucket.
+//
+// requestsPerSecond is the sustained rate, burst is the number of requests
+// which can be sent at once after a period of inactivity.
+// Non-positive burst defaults to the rate rounded up.
+// Every attempt is counted, including API discovery, retries and re-authentication.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *client) {
+		if requestsPerSecond <= 0 {
+			c.rateLimiter = nil
+			return
+		}
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests in flight.
+// Callers exceeding the limit wait for a free slot or until their context is done.
+// Non-positive value removes the limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *client) {
+		if n <= 0 {
+			c.inFlight = nil
+			return
+		}
+		c.inFlight = make(chan struct{}, n)
+	}
+}
+
+// throttle blocks until the request is allowed by concurrency and rate limits of the client.
+//
+// The returned function must be called once the request is complete to free its slot.
+// Time spent waiting is not counted towards request timeout.
+func (c *client) throttle(ctx context.Context) (func(), error) {
+	release := func() {}
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-c.inFlight }
+	}
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// rateLimiter is a token bucket refilled at constant rate up to its capacity.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token from the bucket, blocking until it is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token in advance and returns the time to wait until it is actually available.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns reserved token back to the bucket.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	l := newRateLimiter(10, 2)
+	now := l.last
+
+	testCases := []struct {
+		name     string
+		at       time.Duration
+		expected time.Duration
+	}{
+		{name: "burst", at: 0, expected: 0},
+		{name: "burst exhausted", at: 0, expected: 0},
+		{name: "wait for refill", at: 0, expected: 100 * time.Millisecond},
+		{name: "queued behind previous reservation", at: 0, expected: 200 * time.Millisecond},
+		{name: "partially refilled", at: 250 * time.Millisecond, expected: 50 * time.Millisecond},
+		{name: "refilled up to burst", at: 10 * time.Second, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		assert.InDelta(t, tc.expected, l.reserve(now.Add(tc.at)), float64(time.Millisecond), tc.name)
+	}
+}
+
+func TestRateLimiter_wait(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.wait(ctx), context.DeadlineExceeded)
+	// cancelled reservation must not delay next callers
+	assert.InDelta(t, time.Second, l.reserve(time.Now()), float64(50*time.Millisecond))
+}
+
+func TestDoContext_rateLimit(t *testing.T) {
+	host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	})
+
+	c, err := New(host, true, WithRateLimit(5, 1), WithRequestTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		// waiting for rate limit is not a subject to request timeout
+		require.NoError(t, c.Do(struct{}{}, &testResponse{}))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 350*time.Millisecond)
+}
+
+func TestDoContext_maxConcurrentRequests(t *testing.T) {
+	const limit = 2
+
+	mu := sync.Mutex{}
+	inFlight, maxInFlight := 0, 0
+	host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	})
+
+	c, err := New(host, true, WithMaxConcurrentRequests(limit))
+	require.NoError(t, err)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Do(struct{}{}, &testResponse{}))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, limit, maxInFlight)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, c.DoContext(ctx, struct{}{}, &testResponse{}), context.Canceled)
+}
+
+func TestDoContext_throttleDiscovery(t *testing.T) {
+	host := newTestServer(t, (&sessionServer{}).ServeHTTP)
+
+	c, err := New(host, true, WithRateLimit(5, 1), WithMaxConcurrentRequests(1))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	// API discovery must not wait for the slot already taken by the request it is performed for
+	require.NoError(t, c.LoginContext(ctx, "admin", "secret", "test"))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond, "API discovery must be rate limited")
+}
@@ -0,0 +1,37 @@
+package client_test
+
+import (
+	"testing"
+
+	client "github.com/maksym-nazarenko/terraform-provider-synology/synology-go"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api/filestation"
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo_cassette(t *testing.T) {
+	cas, err := cassette.Load("testdata/filestation.json")
+	require.NoError(t, err)
+	replayer := cassette.NewReplayer(cas)
+	c, err := client.New("nas.invalid:5001", false, client.WithTransport(replayer.Wrap), client.WithAPIErrors())
+	require.NoError(t, err)
+	require.NoError(t, c.Login("api-client", "password", "webui"))
+
+	infoResponse := filestation.FileStationInfoResponse{}
+	require.NoError(t, c.Do(filestation.NewFileStationInfoRequest(2), &infoResponse))
+	assert.Equal(t, "nas", infoResponse.Hostname)
+
+	createFolderRequest := filestation.NewCreateFolderRequest(2).
+		WithFolderPath("/data").
+		WithName("folder")
+	createFolderResponse := filestation.CreateFolderResponse{}
+	require.NoError(t, c.Do(createFolderRequest, &createFolderResponse))
+	require.Len(t, createFolderResponse.Folders, 1)
+	assert.Equal(t, "/data/folder", createFolderResponse.Folders[0].Path)
+
+	err = c.Do(createFolderRequest, &filestation.CreateFolderResponse{})
+	assert.ErrorIs(t, err, api.ErrAlreadyExists)
+	assert.Equal(t, 0, replayer.Unused())
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+// queryOnlyParams are kept in query string for requests with body,
+// so the request can be routed by remote instance before the body is read.
+var queryOnlyParams = []string{"api", "version", "method", "_sid"}
+
+// newHTTPRequest builds HTTP request for API request r with already marshalled params.
+//
+// Requests are sent with GET method and params in query string,
+// unless r implements api.HTTPMethodProvider or api.MultipartRequest.
+// The returned function must be called once the exchange is over: it stops streaming of multipart body
+// and waits until file contents are no longer read, so they can be safely rewound for another attempt.
+func (c *client) newHTTPRequest(ctx context.Context, path string, params url.Values, r api.Request) (*http.Request, func(), error) {
+	u := c.baseURL
+	u.Path += path
+
+	method := http.MethodGet
+	if p, ok := r.(api.HTTPMethodProvider); ok {
+		method = p.HTTPMethod()
+	}
+	var files []api.MultipartFile
+	if p, ok := r.(api.MultipartRequest); ok {
+		method = http.MethodPost
+		files = p.Files()
+	}
+
+	if method != http.MethodPost {
+		u.RawQuery = params.Encode()
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+		return req, func() {}, err
+	}
+
+	query, body := splitParams(params)
+	u.RawQuery = query.Encode()
+	if files == nil {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(body.Encode()))
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return req, func() {}, nil
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	// DSM rejects uploads without Content-Length, so it is set whenever file sizes are known
+	if n, ok := multipartLength(mw.Boundary(), body, files); ok {
+		req.ContentLength = n
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pw.CloseWithError(writeMultipart(mw, body, files))
+	}()
+	release := func() {
+		// transport may give up before the whole body is sent, closing the pipe stops the writer then
+		_ = pr.Close()
+		<-done
+	}
+
+	return req, release, nil
+}
+
+// splitParams separates routing params, which must stay in query string, from the rest.
+func splitParams(params url.Values) (url.Values, url.Values) {
+	query := url.Values{}
+	body := url.Values{}
+	for k, v := range params {
+		body[k] = v
+	}
+	for _, k := range queryOnlyParams {
+		if v, ok := body[k]; ok {
+			query[k] = v
+			delete(body, k)
+		}
+	}
+
+	return query, body
+}
+
+// writeMultipart writes params followed by files into multipart writer.
+func writeMultipart(mw *multipart.Writer, params url.Values, files []api.MultipartFile) error {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range params[k] {
+			if err := mw.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range files {
+		part, err := mw.CreateFormFile(f.FieldName, f.FileName)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, f.Content); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+// multipartLength returns length of body written by writeMultipart with the boundary.
+// Reports false if size of any file is unknown, i.e. its content is not io.Seeker.
+func multipartLength(boundary string, params url.Values, files []api.MultipartFile) (int64, bool) {
+	var size int64
+	headers := make([]api.MultipartFile, 0, len(files))
+	for _, f := range files {
+		n, ok := remainingSize(f.Content)
+		if !ok {
+			return 0, false
+		}
+		size += n
+		headers = append(headers, api.MultipartFile{FieldName: f.FieldName, FileName: f.FileName, Content: strings.NewReader("")})
+	}
+
+	counter := &countingWriter{}
+	mw := multipart.NewWriter(counter)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return 0, false
+	}
+	if err := writeMultipart(mw, params, headers); err != nil {
+		return 0, false
+	}
+
+	return size + counter.n, true
+}
+
+// remainingSize returns number of bytes left to read from seekable reader.
+func remainingSize(r io.Reader) (int64, bool) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, false
+	}
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, false
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, false
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, false
+	}
+
+	return end - current, true
+}
+
+// countingWriter discards written data, counting its length.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// isTransfer reports whether the request uploads files or response receives raw content, e.g. downloaded files.
+func isTransfer(r api.Request, response api.Response) bool {
+	if _, ok := r.(api.MultipartRequest); ok {
+		return true
+	}
+	_, ok := response.(api.RawResponse)
+
+	return ok
+}
+
+// rewindRequest prepares request to be sent once again.
+// It must be called only after the previous attempt is released, see newHTTPRequest.
+// Reports false if request body can't be replayed.
+func rewindRequest(r api.Request) bool {
+	p, ok := r.(api.MultipartRequest)
+	if !ok {
+		return true
+	}
+
+	for _, f := range p.Files() {
+		seeker, ok := f.Content.(io.Seeker)
+		if !ok {
+			return false
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type formRequest struct {
+	APIName   string `synology:"api"`
+	APIMethod string `synology:"method"`
+	Version   int    `synology:"version"`
+	Secret    string `synology:"secret"`
+}
+
+func (r formRequest) HTTPMethod() string {
+	return http.MethodPost
+}
+
+type multipartRequest struct {
+	APIName   string `synology:"api"`
+	APIMethod string `synology:"method"`
+	Version   int    `synology:"version"`
+	Path      string `synology:"path"`
+	content   io.Reader
+}
+
+func (r multipartRequest) Files() []api.MultipartFile {
+	return []api.MultipartFile{
+		{FieldName: "file", FileName: "file.txt", Content: r.content},
+	}
+}
+
+func TestDoContext_requestBody(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request api.Request
+		check   func(t *testing.T, r *http.Request)
+	}{
+		{
+			name:    "query string",
+			request: struct{ Secret string }{Secret: "value"},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "value", r.URL.Query().Get("secret"))
+			},
+		},
+		{
+			name:    "form",
+			request: formRequest{APIName: "SYNO.Test", APIMethod: "set", Version: 1, Secret: "value"},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+				assert.Equal(t, "SYNO.Test", r.URL.Query().Get("api"))
+				assert.Equal(t, "set", r.URL.Query().Get("method"))
+				assert.Equal(t, "1", r.URL.Query().Get("version"))
+				assert.Empty(t, r.URL.Query().Get("secret"))
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "value", r.PostForm.Get("secret"))
+			},
+		},
+		{
+			name: "multipart",
+			request: multipartRequest{
+				APIName:   "SYNO.Test",
+				APIMethod: "upload",
+				Version:   1,
+				Path:      "/share/folder",
+				content:   strings.NewReader("file content"),
+			},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "upload", r.URL.Query().Get("method"))
+				assert.Positive(t, r.ContentLength, "Content-Length must be set for seekable content")
+				require.NoError(t, r.ParseMultipartForm(1024))
+				assert.Equal(t, "/share/folder", r.MultipartForm.Value["path"][0])
+
+				file, header, err := r.FormFile("file")
+				require.NoError(t, err)
+				defer file.Close()
+				assert.Equal(t, "file.txt", header.Filename)
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				assert.Equal(t, "file content", string(content))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("api") == "SYNO.API.Info" {
+					fmt.Fprint(w, `{"success":true,"data":{"SYNO.Test":{"path":"entry.cgi","minVersion":1,"maxVersion":1}}}`)
+					return
+				}
+				tc.check(t, r)
+				fmt.Fprint(w, `{"success":true,"data":{}}`)
+			})
+			c, err := New(host, true)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Do(tc.request, &testResponse{}))
+		})
+	}
+}
+
+func TestMultipartLength(t *testing.T) {
+	params := url.Values{"path": {"/share/folder"}, "overwrite": {"true"}}
+	files := []api.MultipartFile{
+		{FieldName: "file", FileName: "a.txt", Content: strings.NewReader("first file")},
+		{FieldName: "file", FileName: "b.txt", Content: bytes.NewReader([]byte("second"))},
+	}
+
+	buf := &bytes.Buffer{}
+	mw := multipart.NewWriter(buf)
+	n, ok := multipartLength(mw.Boundary(), params, files)
+	require.True(t, ok)
+	require.NoError(t, writeMultipart(mw, params, files))
+	assert.Equal(t, int64(buf.Len()), n)
+
+	partiallyRead := strings.NewReader("content")
+	_, _ = partiallyRead.Read(make([]byte, 3))
+	n, ok = remainingSize(partiallyRead)
+	require.True(t, ok)
+	assert.Equal(t, int64(4), n)
+	assert.Equal(t, 4, partiallyRead.Len(), "read position must be kept")
+
+	_, ok = multipartLength(mw.Boundary(), params, []api.MultipartFile{
+		{FieldName: "file", FileName: "a.txt", Content: io.MultiReader(strings.NewReader("content"))},
+	})
+	assert.False(t, ok, "length of non-seekable content is unknown")
+}
+
+func TestRewindRequest(t *testing.T) {
+	reader := strings.NewReader("content")
+	_, _ = io.ReadAll(reader)
+
+	assert.True(t, rewindRequest(formRequest{}))
+	assert.True(t, rewindRequest(multipartRequest{content: reader}))
+	assert.Equal(t, int64(len("content")), reader.Size())
+	assert.Equal(t, len("content"), reader.Len(), "reader must be rewound")
+	assert.False(t, rewindRequest(multipartRequest{content: io.MultiReader(reader)}))
+}
+
+// slowReader produces endless content, every read after the first one is slow.
+type slowReader struct {
+	reads  int32
+	active int32
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	atomic.AddInt32(&r.active, 1)
+	defer atomic.AddInt32(&r.active, -1)
+	if atomic.AddInt32(&r.reads, 1) > 1 {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return copy(p, "content"), nil
+}
+
+func TestNewHTTPRequest_release(t *testing.T) {
+	c, err := New("nas.invalid:5001", true)
+	require.NoError(t, err)
+	content := &slowReader{}
+	req, release, err := c.(*client).newHTTPRequest(context.Background(), "/webapi/entry.cgi", url.Values{}, multipartRequest{content: content})
+	require.NoError(t, err)
+
+	// transport gives up after a part of the body is sent
+	for atomic.LoadInt32(&content.reads) < 2 {
+		_, err = req.Body.Read(make([]byte, 1024))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&content.active) == 1
+	}, time.Second, time.Millisecond)
+
+	release()
+	assert.Zero(t, atomic.LoadInt32(&content.active), "content must not be read once request is released")
+}
+
+func TestDoContext_transferTimeout(t *testing.T) {
+	host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	})
+
+	testCases := []struct {
+		name     string
+		request  api.Request
+		response api.Response
+		expected error
+	}{
+		{
+			name:     "regular request",
+			request:  struct{}{},
+			response: &testResponse{},
+			expected: context.DeadlineExceeded,
+		},
+		{
+			name:     "upload",
+			request:  multipartRequest{content: strings.NewReader("content")},
+			response: &testResponse{},
+		},
+		{
+			name:     "download",
+			request:  struct{}{},
+			response: &rawResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(host, true, WithRequestTimeout(20*time.Millisecond))
+			require.NoError(t, err)
+
+			err = c.Do(tc.request, tc.response)
+			if tc.expected != nil {
+				assert.ErrorIs(t, err, tc.expected)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"mime"
+	"net/http"
+)
+
+// isJSONEnvelope reports whether HTTP response to a request with api.RawResponse
+// carries JSON envelope instead of content, which is how DSM reports failures of such requests.
+func isJSONEnvelope(header http.Header) bool {
+	if header.Get("Content-Disposition") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || mediaType == "text/plain"
+}
+
+// streamError is returned when api.RawResponse fails to consume response body.
+// Such requests are not retried, since the content may be partially consumed already.
+type streamError struct {
+	err error
+}
+
+// Error satisfies error interface for streamError type.
+func (e streamError) Error() string {
+	return "failed to read response body: " + e.err.Error()
+}
+
+// Unwrap returns error reported by response.
+func (e streamError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rawResponse struct {
+	testResponse
+	contentType string
+	body        bytes.Buffer
+}
+
+func (r *rawResponse) ReadBody(header http.Header, body io.Reader) error {
+	r.contentType = header.Get("Content-Type")
+	_, err := io.Copy(&r.body, body)
+
+	return err
+}
+
+func TestDoContext_rawResponse(t *testing.T) {
+	testCases := []struct {
+		name                string
+		header              http.Header
+		body                string
+		expectedBody        string
+		expectedContentType string
+		expectedCode        int
+	}{
+		{
+			name: "content",
+			header: http.Header{
+				"Content-Type":        {"application/octet-stream"},
+				"Content-Disposition": {`attachment; filename="file.bin"`},
+			},
+			body:                "content",
+			expectedBody:        "content",
+			expectedContentType: "application/octet-stream",
+		},
+		{
+			name: "JSON content",
+			header: http.Header{
+				"Content-Type":        {"application/json"},
+				"Content-Disposition": {`attachment; filename="file.json"`},
+			},
+			body:                `{"success":false}`,
+			expectedBody:        `{"success":false}`,
+			expectedContentType: "application/json",
+		},
+		{
+			name:         "error envelope",
+			header:       http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			body:         `{"success":false,"error":{"code":408}}`,
+			expectedCode: 408,
+		},
+		{
+			name:         "plain text error envelope",
+			header:       http.Header{"Content-Type": {`text/plain; charset="UTF-8"`}},
+			body:         `{"success":false,"error":{"code":408}}`,
+			expectedCode: 408,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tc.header {
+					w.Header()[k] = v
+				}
+				fmt.Fprint(w, tc.body)
+			})
+			c, err := New(host, true)
+			require.NoError(t, err)
+
+			response := rawResponse{}
+			require.NoError(t, c.Do(struct{}{}, &response))
+			assert.Equal(t, tc.expectedCode, response.GetError().Code)
+			assert.Equal(t, tc.expectedBody, response.body.String())
+			assert.Equal(t, tc.expectedContentType, response.contentType)
+		})
+	}
+}
+
+func TestDoContext_rawResponseNotRetried(t *testing.T) {
+	attempts := 0
+	host := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", "attachment")
+		// connection is closed before the declared content is sent
+		w.Header().Set("Content-Length", "100")
+		fmt.Fprint(w, "partial")
+	})
+	c, err := New(host, true, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}))
+	require.NoError(t, err)
+
+	response := rawResponse{}
+	err = c.Do(struct{}{}, &response)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "partial", response.body.String())
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of attempts per request, including the first one.
+	DefaultRetryMaxAttempts = 3
+
+	// DefaultRetryMinBackoff is the default delay before the first retry.
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the default upper limit of delay between attempts.
+	DefaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy defines how requests failed with transient errors are retried.
+//
+// Transport errors are retried only for idempotent requests, see api.IdempotencyProvider,
+// unless the connection to remote instance could not be established at all.
+// API errors listed in RetryableErrors are retried for any request,
+// since remote instance rejects such requests without processing them.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry, it doubles with every next attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff limits the delay between attempts. Zero value means no limit.
+	MaxBackoff time.Duration
+
+	// RetryableErrors are matched against API error of the response with errors.Is.
+	RetryableErrors []error
+}
+
+// DefaultRetryPolicy returns policy retrying requests when remote instance is busy or unreachable.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     DefaultRetryMaxAttempts,
+		MinBackoff:      DefaultRetryMinBackoff,
+		MaxBackoff:      DefaultRetryMaxBackoff,
+		RetryableErrors: []error{api.ErrBusy},
+	}
+}
+
+// WithRetryPolicy enables retries of requests failed with transient errors.
+// Requests are not retried by default.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// backoff returns delay before the attempt following the given one.
+//
+// The delay grows exponentially, and a random jitter of up to a half of it is applied,
+// so concurrent clients don't retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && delay < math.MaxInt64/2; i++ {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryable reports whether the outcome of a request attempt is worth retrying.
+func (p RetryPolicy) retryable(ctx context.Context, r api.Request, response api.Response, err error) bool {
+	// caller gave up, so there is nobody to retry for
+	if ctx.Err() != nil {
+		return false
+	}
+	if err == nil {
+		if response.Success() {
+			return false
+		}
+		apiErr := response.GetError()
+		for _, target := range p.RetryableErrors {
+			if errors.Is(apiErr, target) {
+				return true
+			}
+		}
+
+		return false
+	}
+	if isDialError(err) {
+		return true
+	}
+	// response has consumed a part of content already
+	if errors.As(err, &streamError{}) {
+		return false
+	}
+
+	return isIdempotent(r) && isTransientError(err)
+}
+
+// doWithRetry performs a request, retrying it according to retry policy of the client.
+func (c *client) doWithRetry(ctx context.Context, r api.Request, response api.Response) error {
+	for attempt := 1; ; attempt++ {
+		err := c.doWithTimeout(ctx, r, response)
+		if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.retryable(ctx, r, response, err) || !rewindRequest(r) {
+			return err
+		}
+
+		timer := time.NewTimer(c.retryPolicy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err != nil {
+				return err
+			}
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+		response.SetError(api.SynologyError{})
+	}
+}
+
+// isIdempotent reports whether the request can be safely sent more than once.
+func isIdempotent(r api.Request) bool {
+	if p, ok := r.(api.IdempotencyProvider); ok {
+		return p.Idempotent()
+	}
+	if _, ok := r.(api.MultipartRequest); ok {
+		return false
+	}
+	if p, ok := r.(api.HTTPMethodProvider); ok {
+		switch p.HTTPMethod() {
+		case http.MethodGet, http.MethodHead:
+			return true
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// isDialError reports whether the error happened before the request was sent,
+// so remote instance could not have processed it.
+func isDialError(err error) bool {
+	opErr := &net.OpError{}
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransientError reports whether the error is caused by temporary network or server conditions.
+func isTransientError(err error) bool {
+	statusErr := HTTPStatusError{}
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+
+		return false
+	}
+
+	netErr := net.Error(nil)
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+)
+
+const (
+	// DefaultTaskMinPollInterval is the default delay before the first status request of a task.
+	DefaultTaskMinPollInterval = 200 * time.Millisecond
+
+	// DefaultTaskMaxPollInterval is the default upper limit of delay between status requests of a task.
+	DefaultTaskMaxPollInterval = 5 * time.Second
+)
+
+// TaskStart is a response of request starting asynchronous task.
+type TaskStart interface {
+	api.Response
+
+	// TaskID returns ID of the started task.
+	TaskID() string
+}
+
+// TaskStatus is a response of task status request.
+type TaskStatus interface {
+	api.Response
+
+	// Finished reports whether the task is complete.
+	Finished() bool
+
+	// Progress returns completion ratio in range [0, 1], or negative value if it is unknown.
+	Progress() float64
+}
+
+// Task describes asynchronous API operation, e.g. FileStation delete or copy,
+// which is started by one request and then polled by another one until it is finished.
+type Task[Status TaskStatus] struct {
+	// Start returns request starting the task and response to decode task ID into.
+	Start func() (api.Request, TaskStart)
+
+	// Status returns request for status of the task with ID and response to decode the status into.
+	Status func(taskID string) (api.Request, Status)
+
+	// Stop returns request cancelling the task with ID and response to decode the result into.
+	// It is optional, the task keeps running on remote instance on cancellation if it is not set.
+	Stop func(taskID string) (api.Request, api.Response)
+}
+
+// TaskOption defines a function to customize task execution.
+type TaskOption func(*taskOptions)
+
+type taskOptions struct {
+	minPollInterval time.Duration
+	maxPollInterval time.Duration
+	progress        func(status TaskStatus)
+}
+
+// WithPollInterval sets the delay before the first status request of a task,
+// it doubles with every next request up to maxInterval.
+func WithPollInterval(minInterval, maxInterval time.Duration) TaskOption {
+	return func(o *taskOptions) {
+		o.minPollInterval = minInterval
+		o.maxPollInterval = maxInterval
+	}
+}
+
+// WithProgress sets function called with every received task status, including the final one.
+func WithProgress(fn func(status TaskStatus)) TaskOption {
+	return func(o *taskOptions) {
+		o.progress = fn
+	}
+}
+
+// RunTask starts the task and polls its status until it is finished, returning the final status.
+//
+// If ctx is done before the task is finished, the task is stopped on remote instance,
+// if it supports that, and ctx error is returned.
+// API failures are returned as errors regardless of WithAPIErrors option.
+func RunTask[Status TaskStatus](ctx context.Context, c Client, task Task[Status], options ...TaskOption) (Status, error) {
+	var status Status
+	opts := taskOptions{
+		minPollInterval: DefaultTaskMinPollInterval,
+		maxPollInterval: DefaultTaskMaxPollInterval,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	startRequest, started := task.Start()
+	if err := doTaskRequest(ctx, c, startRequest, started); err != nil {
+		return status, fmt.Errorf("task start failed: %w", err)
+	}
+	taskID := started.TaskID()
+
+	poll := RetryPolicy{MinBackoff: opts.minPollInterval, MaxBackoff: opts.maxPollInterval}
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(poll.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, stopTask(c, task, taskID, ctx.Err())
+		case <-timer.C:
+		}
+
+		statusRequest, current := task.Status(taskID)
+		if err := doTaskRequest(ctx, c, statusRequest, current); err != nil {
+			if ctx.Err() != nil {
+				return status, stopTask(c, task, taskID, ctx.Err())
+			}
+			return status, fmt.Errorf("task %s status failed: %w", taskID, err)
+		}
+		status = current
+		if opts.progress != nil {
+			opts.progress(status)
+		}
+		if status.Finished() {
+			return status, nil
+		}
+	}
+}
+
+// doTaskRequest performs request of a task, returning API failure as error.
+func doTaskRequest(ctx context.Context, c Client, r api.Request, response api.Response) error {
+	if err := c.DoContext(ctx, r, response); err != nil {
+		return err
+	}
+	if !response.Success() {
+		return response.GetError()
+	}
+
+	return nil
+}
+
+// stopTask stops the task cancelled by caller, returning cause along with stop failure, if any.
+func stopTask[Status TaskStatus](c Client, task Task[Status], taskID string, cause error) error {
+	if task.Stop == nil {
+		return cause
+	}
+
+	// caller's context is already done, so the request is limited by request timeout only
+	stopRequest, stopped := task.Stop(taskID)
+	if err := doTaskRequest(context.Background(), c, stopRequest, stopped); err != nil {
+		return fmt.Errorf("%w, task %s stop failed: %s", cause, taskID, err)
+	}
+
+	return cause
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maksym-nazarenko/terraform-provider-synology/synology-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type taskRequest struct {
+	Method string `synology:"method"`
+	TaskID string `synology:"taskid,omitempty"`
+}
+
+type taskStartResponse struct {
+	testResponse
+
+	ID string `synology:"taskid"`
+}
+
+func (r *taskStartResponse) TaskID() string {
+	return r.ID
+}
+
+type taskStatusResponse struct {
+	testResponse
+
+	Done      bool    `synology:"finished"`
+	Processed float64 `synology:"progress"`
+}
+
+func (r *taskStatusResponse) Finished() bool {
+	return r.Done
+}
+
+func (r *taskStatusResponse) Progress() float64 {
+	return r.Processed
+}
+
+var testTask = Task[*taskStatusResponse]{
+	Start: func() (api.Request, TaskStart) {
+		return taskRequest{Method: "start"}, &taskStartResponse{}
+	},
+	Status: func(taskID string) (api.Request, *taskStatusResponse) {
+		return taskRequest{Method: "status", TaskID: taskID}, &taskStatusResponse{}
+	},
+	Stop: func(taskID string) (api.Request, api.Response) {
+		return taskRequest{Method: "stop", TaskID: taskID}, &testResponse{}
+	},
+}
+
+// taskServer emulates task finished after a number of status requests.
+type taskServer struct {
+	mu        sync.Mutex
+	steps     int
+	statuses  int
+	stopped   []string
+	startCode int
+}
+
+func (s *taskServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Query().Get("method") {
+	case "start":
+		if s.startCode != 0 {
+			fmt.Fprintf(w, `{"success":false,"error":{"code":%d}}`, s.startCode)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"taskid":"task-1"}}`)
+	case "status":
+		if r.URL.Query().Get("taskid") != "task-1" {
+			fmt.Fprint(w, `{"success":false,"error":{"code":599}}`)
+			return
+		}
+		s.statuses++
+		fmt.Fprintf(w, `{"success":true,"data":{"finished":%t,"progress":%g}}`,
+			s.statuses >= s.steps, float64(s.statuses)/float64(s.steps))
+	case "stop":
+		s.stopped = append(s.stopped, r.URL.Query().Get("taskid"))
+		fmt.Fprint(w, `{"success":true}`)
+	}
+}
+
+func TestRunTask(t *testing.T) {
+	srv := &taskServer{steps: 4}
+	c, err := New(newTestServer(t, srv.ServeHTTP), true)
+	require.NoError(t, err)
+
+	progress := []float64{}
+	status, err := RunTask(context.Background(), c, testTask,
+		WithPollInterval(time.Millisecond, 4*time.Millisecond),
+		WithProgress(func(status TaskStatus) {
+			progress = append(progress, status.Progress())
+		}),
+	)
+	require.NoError(t, err)
+	assert.True(t, status.Finished())
+	assert.Equal(t, []float64{0.25, 0.5, 0.75, 1}, progress)
+	assert.Empty(t, srv.stopped)
+}
+
+func TestRunTask_cancel(t *testing.T) {
+	srv := &taskServer{steps: 1000}
+	c, err := New(newTestServer(t, srv.ServeHTTP), true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	status, err := RunTask(ctx, c, testTask, WithPollInterval(time.Millisecond, 5*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, status, "last received status is returned")
+	assert.False(t, status.Finished())
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, []string{"task-1"}, srv.stopped)
+}
+
+func TestRunTask_errors(t *testing.T) {
+	srv := &taskServer{startCode: 105}
+	c, err := New(newTestServer(t, srv.ServeHTTP), true)
+	require.NoError(t, err)
+
+	_, err = RunTask(context.Background(), c, testTask)
+	assert.ErrorIs(t, err, api.ErrPermissionDenied)
+	assert.ErrorContains(t, err, "task start failed")
+
+	srv.startCode = 0
+	lostTask := testTask
+	lostTask.Status = func(taskID string) (api.Request, *taskStatusResponse) {
+		return taskRequest{Method: "status", TaskID: "unknown"}, &taskStatusResponse{}
+	}
+	_, err = RunTask(context.Background(), c, lostTask, WithPollInterval(time.Millisecond, time.Millisecond))
+	synoErr := api.SynologyError{}
+	require.ErrorAs(t, err, &synoErr)
+	assert.Equal(t, 599, synoErr.Code)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// tlsSettings customize TLS connections to remote instance.
+type tlsSettings struct {
+	rootCAs      *x509.CertPool
+	certificates []tls.Certificate
+	serverName   string
+	fingerprint  []byte
+}
+
+// WithRootCAs sets certificate authorities to verify certificate of remote instance with,
+// e.g. when it is issued by an internal CA. System roots are not used in this case.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *client) {
+		c.tls.rootCAs = pool
+	}
+}
+
+// WithClientCertificate sets certificate presented to remote instance or reverse proxy in front of it
+// when it requests mutual TLS authentication.
+func WithClientCertificate(certificate tls.Certificate) Option {
+	return func(c *client) {
+		c.tls.certificates = append(c.tls.certificates, certificate)
+	}
+}
+
+// WithServerName overrides host name used for SNI and certificate verification,
+// e.g. when remote instance is accessed by IP address.
+func WithServerName(serverName string) Option {
+	return func(c *client) {
+		c.tls.serverName = serverName
+	}
+}
+
+// WithCertificateFingerprint pins SHA-256 fingerprint of remote instance certificate.
+//
+// Remote instance is trusted if and only if its leaf certificate matches the fingerprint,
+// so self-signed certificates can be used without disabling verification.
+// Use ParseCertificateFingerprint to decode fingerprint in a human-readable form.
+func WithCertificateFingerprint(fingerprint [sha256.Size]byte) Option {
+	return func(c *client) {
+		c.tls.fingerprint = fingerprint[:]
+	}
+}
+
+// ParseCertificateFingerprint decodes hex-encoded SHA-256 fingerprint,
+// optionally with bytes separated by colons, e.g. as shown by 'openssl x509 -fingerprint -sha256'.
+func ParseCertificateFingerprint(s string) ([sha256.Size]byte, error) {
+	fingerprint := [sha256.Size]byte{}
+	b, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(s), ":", ""))
+	if err != nil {
+		return fingerprint, fmt.Errorf("invalid certificate fingerprint: %w", err)
+	}
+	if len(b) != sha256.Size {
+		return fingerprint, fmt.Errorf("invalid certificate fingerprint: expected %d bytes, got %d", sha256.Size, len(b))
+	}
+	copy(fingerprint[:], b)
+
+	return fingerprint, nil
+}
+
+// config returns TLS configuration for the transport.
+func (s tlsSettings) config(skipCertificateVerification bool) *tls.Config {
+	config := &tls.Config{
+		InsecureSkipVerify: skipCertificateVerification,
+		RootCAs:            s.rootCAs,
+		Certificates:       s.certificates,
+		ServerName:         s.serverName,
+	}
+	if s.fingerprint != nil {
+		// pinned certificate replaces chain verification
+		fingerprint := s.fingerprint
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("remote instance presented no certificate")
+			}
+			sum := sha256.Sum256(state.PeerCertificates[0].Raw)
+			if !bytes.Equal(sum[:], fingerprint) {
+				return fmt.Errorf("certificate fingerprint mismatch: got %X", sum)
+			}
+
+			return nil
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientCertificate generates self-signed certificate for mutual TLS.
+func newClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestNew_tls(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	}))
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	mtlsSrv := httptest.NewUnstartedServer(srv.Config.Handler)
+	mtlsSrv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	mtlsSrv.StartTLS()
+	t.Cleanup(mtlsSrv.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	mtlsRoots := x509.NewCertPool()
+	mtlsRoots.AddCert(mtlsSrv.Certificate())
+	fingerprint := sha256.Sum256(srv.Certificate().Raw)
+
+	testCases := []struct {
+		name          string
+		server        *httptest.Server
+		skipCertCheck bool
+		options       []Option
+		expectedErr   string
+	}{
+		{
+			name:        "unknown authority",
+			server:      srv,
+			expectedErr: "certificate signed by unknown authority",
+		},
+		{
+			name:          "verification disabled",
+			server:        srv,
+			skipCertCheck: true,
+		},
+		{
+			name:    "custom root CA",
+			server:  srv,
+			options: []Option{WithRootCAs(roots)},
+		},
+		{
+			name:    "server name override",
+			server:  srv,
+			options: []Option{WithRootCAs(roots), WithServerName("example.com")},
+		},
+		{
+			name:        "server name mismatch",
+			server:      srv,
+			options:     []Option{WithRootCAs(roots), WithServerName("nas.local")},
+			expectedErr: "certificate is valid for",
+		},
+		{
+			name:    "pinned fingerprint",
+			server:  srv,
+			options: []Option{WithCertificateFingerprint(fingerprint)},
+		},
+		{
+			name:        "pinned fingerprint mismatch",
+			server:      srv,
+			options:     []Option{WithCertificateFingerprint([sha256.Size]byte{})},
+			expectedErr: "certificate fingerprint mismatch",
+		},
+		{
+			name:        "client certificate required",
+			server:      mtlsSrv,
+			options:     []Option{WithRootCAs(mtlsRoots)},
+			expectedErr: "certificate required",
+		},
+		{
+			name:    "client certificate",
+			server:  mtlsSrv,
+			options: []Option{WithRootCAs(mtlsRoots), WithClientCertificate(newClientCertificate(t))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(strings.TrimPrefix(tc.server.URL, "https://"), tc.skipCertCheck, tc.options...)
+			require.NoError(t, err)
+			err = c.Do(struct{}{}, &testResponse{})
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCertificateFingerprint(t *testing.T) {
+	expected := [sha256.Size]byte{0xab, 0xcd}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr bool
+	}{
+		{
+			name:  "hex",
+			input: "abcd" + strings.Repeat("00", 30),
+		},
+		{
+			name:  "colon separated",
+			input: " AB:CD:" + strings.Repeat("00:", 29) + "00 ",
+		},
+		{
+			name:        "invalid hex",
+			input:       "not a fingerprint",
+			expectedErr: true,
+		},
+		{
+			name:        "SHA-1 fingerprint",
+			input:       strings.Repeat("00", 20),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fingerprint, err := ParseCertificateFingerprint(tc.input)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected, fingerprint)
+		})
+	}
+}
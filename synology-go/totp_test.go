@@ -0,0 +1,53 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTOTP(t *testing.T) {
+	// test vectors from RFC 6238, truncated to 6 digits
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	testCases := []struct {
+		name     string
+		secret   string
+		time     time.Time
+		expected string
+	}{
+		{
+			name:     "59",
+			secret:   secret,
+			time:     time.Unix(59, 0),
+			expected: "287082",
+		},
+		{
+			name:     "1111111109",
+			secret:   secret,
+			time:     time.Unix(1111111109, 0),
+			expected: "081804",
+		},
+		{
+			name:     "lowercase with spaces",
+			secret:   "gezd gnbv gy3t qojq gezd gnbv gy3t qojq",
+			time:     time.Unix(2000000000, 0),
+			expected: "279037",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := GenerateTOTP(tc.secret, tc.time)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, code)
+		})
+	}
+}
+
+func TestGenerateTOTP_invalidSecret(t *testing.T) {
+	_, err := GenerateTOTP("not base32!", time.Now())
+	assert.Error(t, err)
+}